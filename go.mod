@@ -2,15 +2,33 @@ module github.com/arraniry/capy
 
 go 1.21.5
 
-require github.com/lib/pq v1.10.9
+require (
+	github.com/glebarez/go-sqlite v1.21.2
+	github.com/glebarez/sqlite v1.11.0
+	github.com/go-sql-driver/mysql v1.7.1
+	github.com/gorilla/mux v1.8.1
+	github.com/jinzhu/inflection v1.0.0
+	github.com/lib/pq v1.10.9
+	github.com/spf13/cobra v1.9.1
+	golang.org/x/mod v0.17.0
+	golang.org/x/term v0.10.0
+	google.golang.org/protobuf v1.34.1
+	gopkg.in/yaml.v3 v3.0.1
+	gorm.io/gorm v1.25.12
+)
 
 require (
-	github.com/gorilla/mux v1.8.1 // indirect
+	github.com/dustin/go-humanize v1.0.1 // indirect
+	github.com/google/uuid v1.3.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
-	github.com/spf13/cobra v1.9.1 // indirect
+	github.com/mattn/go-isatty v0.0.17 // indirect
+	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
 	github.com/spf13/pflag v1.0.6 // indirect
+	golang.org/x/sys v0.10.0 // indirect
 	golang.org/x/text v0.22.0 // indirect
-	gorm.io/gorm v1.25.12 // indirect
+	modernc.org/libc v1.22.5 // indirect
+	modernc.org/mathutil v1.5.0 // indirect
+	modernc.org/memory v1.5.0 // indirect
+	modernc.org/sqlite v1.23.1 // indirect
 )
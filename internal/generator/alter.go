@@ -0,0 +1,287 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// schemaStatements menghasilkan DDL untuk mengubah skema from menjadi to.
+// renames memetakan tabel ke pasangan kolom lama -> kolom baru; tanpa
+// rename, kolom yang namanya berubah dianggap dihapus lalu ditambahkan.
+func schemaStatements(d *sqlDialect, from, to []table, renames map[string]map[string]string) []string {
+	var stmts []string
+	fromTables := tablesByName(from)
+	toTables := tablesByName(to)
+
+	for _, t := range to {
+		old, ok := fromTables[t.Name]
+		if !ok {
+			stmts = append(stmts, d.createTable(t)...)
+			continue
+		}
+		stmts = append(stmts, d.alterTable(old, t, renames[t.Name])...)
+	}
+	for _, t := range from {
+		if _, ok := toTables[t.Name]; !ok {
+			stmts = append(stmts, d.dropTable(t.Name))
+		}
+	}
+	return stmts
+}
+
+// invertRenames membalik arah rename untuk migration down
+func invertRenames(renames map[string]map[string]string) map[string]map[string]string {
+	inverted := make(map[string]map[string]string, len(renames))
+	for t, cols := range renames {
+		inverted[t] = make(map[string]string, len(cols))
+		for from, to := range cols {
+			inverted[t][to] = from
+		}
+	}
+	return inverted
+}
+
+// tableChanges adalah perbedaan satu tabel antara dua skema
+type tableChanges struct {
+	renamed        map[string]string // kolom lama -> kolom baru
+	added          []column
+	removed        []column
+	changed        []column // definisi baru kolom yang tipe/null/default-nya berubah
+	addedIndexes   []index
+	removedIndexes []index
+}
+
+func (c tableChanges) empty() bool {
+	return len(c.renamed) == 0 && len(c.added) == 0 && len(c.removed) == 0 &&
+		len(c.changed) == 0 && len(c.addedIndexes) == 0 && len(c.removedIndexes) == 0
+}
+
+// compareTables menghitung perbedaan tabel from dan to
+func compareTables(from, to table, renames map[string]string) tableChanges {
+	changes := tableChanges{renamed: make(map[string]string)}
+
+	// source memetakan kolom to ke nama kolom asalnya pada from
+	source := make(map[string]string)
+	for _, c := range to.Columns {
+		source[c.Name] = c.Name
+	}
+	for old, name := range renames {
+		if _, ok := source[name]; ok && from.column(old) != nil {
+			source[name] = old
+			changes.renamed[old] = name
+		}
+	}
+
+	matched := make(map[string]bool)
+	for _, c := range to.Columns {
+		old := from.column(source[c.Name])
+		if old == nil {
+			changes.added = append(changes.added, c)
+			continue
+		}
+		matched[old.Name] = true
+		if !sameColumnDef(*old, c) {
+			changes.changed = append(changes.changed, c)
+		}
+	}
+	for _, c := range from.Columns {
+		if !matched[c.Name] {
+			changes.removed = append(changes.removed, c)
+		}
+	}
+
+	for _, idx := range from.Indexes {
+		if !to.hasIndex(idx.renamed(changes.renamed)) {
+			changes.removedIndexes = append(changes.removedIndexes, idx)
+		}
+	}
+	for _, idx := range to.Indexes {
+		if !from.hasIndex(idx.renamed(invertMap(changes.renamed))) {
+			changes.addedIndexes = append(changes.addedIndexes, idx)
+		}
+	}
+	return changes
+}
+
+// alterTable menghasilkan DDL untuk mengubah tabel from menjadi to
+func (d *sqlDialect) alterTable(from, to table, renames map[string]string) []string {
+	changes := compareTables(from, to, renames)
+	if changes.empty() {
+		return nil
+	}
+	if d.rebuildRequired(changes) {
+		return d.rebuildTable(from, to, changes.renamed)
+	}
+
+	var stmts []string
+	name := d.quote(to.Name)
+	for _, idx := range changes.removedIndexes {
+		stmts = append(stmts, d.dropIndex(from.Name, idx.Name))
+	}
+	for _, c := range changes.removed {
+		if c.References != "" {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s %s %s", name, d.dropForeignKey, d.quote(foreignKeyName(from.Name, c.Name))))
+		}
+	}
+	for _, old := range sortedKeys(changes.renamed) {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s", name, d.quote(old), d.quote(changes.renamed[old])))
+	}
+	for _, c := range changes.removed {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", name, d.quote(c.Name)))
+	}
+	for _, c := range changes.added {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", name, d.columnDef(c)))
+		if c.References != "" {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ADD %s", name, d.foreignKey(to.Name, c)))
+		}
+	}
+	for _, c := range changes.changed {
+		stmts = append(stmts, d.alterColumn(to.Name, c))
+	}
+	for _, idx := range changes.addedIndexes {
+		stmts = append(stmts, d.createIndex(to.Name, idx))
+	}
+	return stmts
+}
+
+// rebuildRequired menandakan perubahan yang tidak dapat dilakukan dengan
+// ALTER TABLE pada dialect ini (SQLite tidak mendukung perubahan definisi
+// kolom maupun constraint foreign key)
+func (d *sqlDialect) rebuildRequired(changes tableChanges) bool {
+	if d.alterColumnFormat != "" {
+		return false
+	}
+	if len(changes.changed) > 0 {
+		return true
+	}
+	for _, c := range changes.added {
+		if c.References != "" || c.PrimaryKey || (!c.Nullable && !c.HasDefault) {
+			return true
+		}
+	}
+	for _, c := range changes.removed {
+		if c.References != "" || c.PrimaryKey {
+			return true
+		}
+	}
+	return false
+}
+
+// rebuildTable membuat ulang tabel dengan definisi baru lalu menyalin datanya,
+// cara yang dianjurkan SQLite untuk perubahan yang tidak didukung ALTER TABLE
+func (d *sqlDialect) rebuildTable(from, to table, renamed map[string]string) []string {
+	tmp := to
+	tmp.Name = to.Name + "__capy_new"
+
+	source := invertMap(renamed)
+	var dst, src []string
+	for _, c := range to.Columns {
+		old := c.Name
+		if name, ok := source[c.Name]; ok {
+			old = name
+		}
+		if from.column(old) != nil {
+			dst = append(dst, d.quote(c.Name))
+			src = append(src, d.quote(old))
+		}
+	}
+
+	stmts := []string{d.createTableNamed(tmp, to.Name)}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s", d.quote(tmp.Name), strings.Join(dst, ", "), strings.Join(src, ", "), d.quote(from.Name)),
+		"DROP TABLE "+d.quote(from.Name),
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", d.quote(tmp.Name), d.quote(to.Name)),
+	)
+	for _, idx := range to.Indexes {
+		stmts = append(stmts, d.createIndex(to.Name, idx))
+	}
+	return stmts
+}
+
+// alterColumn mengubah tipe, nullability, dan default kolom c
+func (d *sqlDialect) alterColumn(tableName string, c column) string {
+	null := "DROP NOT NULL"
+	if !c.Nullable {
+		null = "SET NOT NULL"
+	}
+	def := "DROP DEFAULT"
+	if c.HasDefault {
+		def = "SET DEFAULT " + sqlLiteral(c.Type, c.Default)
+	}
+	r := strings.NewReplacer(
+		"{table}", d.quote(tableName),
+		"{column}", d.quote(c.Name),
+		"{type}", d.columnType(c),
+		"{definition}", d.columnDef(c),
+		"{null}", null,
+		"{default}", def,
+	)
+	return r.Replace(d.alterColumnFormat)
+}
+
+func (d *sqlDialect) dropIndex(tableName, name string) string {
+	return strings.NewReplacer("{table}", d.quote(tableName), "{index}", d.quote(name)).Replace(d.dropIndexFormat)
+}
+
+// sameColumnDef membandingkan definisi kolom tanpa memperhatikan namanya
+func sameColumnDef(a, b column) bool {
+	return a.Type == b.Type && a.Size == b.Size && a.Nullable == b.Nullable && a.PrimaryKey == b.PrimaryKey &&
+		a.Unique == b.Unique && a.HasDefault == b.HasDefault && a.Default == b.Default
+}
+
+func (t table) column(name string) *column {
+	for i := range t.Columns {
+		if t.Columns[i].Name == name {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+func (t table) hasIndex(idx index) bool {
+	for _, other := range t.Indexes {
+		if other.Name == idx.Name && other.Unique == idx.Unique && strings.Join(other.Columns, ",") == strings.Join(idx.Columns, ",") {
+			return true
+		}
+	}
+	return false
+}
+
+// renamed mengembalikan index dengan nama kolom yang sudah di-rename
+func (idx index) renamed(renames map[string]string) index {
+	cols := make([]string, len(idx.Columns))
+	for i, c := range idx.Columns {
+		if name, ok := renames[c]; ok {
+			c = name
+		}
+		cols[i] = c
+	}
+	idx.Columns = cols
+	return idx
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func tablesByName(tables []table) map[string]table {
+	m := make(map[string]table, len(tables))
+	for _, t := range tables {
+		m[t.Name] = t
+	}
+	return m
+}
+
+func invertMap(m map[string]string) map[string]string {
+	inverted := make(map[string]string, len(m))
+	for k, v := range m {
+		inverted[v] = k
+	}
+	return inverted
+}
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSchemaStatements(t *testing.T) {
+	id := column{Name: "id", Type: "uint", PrimaryKey: true}
+	products := table{
+		Name:    "products",
+		Columns: []column{id, {Name: "title", Type: "string"}, {Name: "price", Type: "decimal"}},
+		Indexes: []index{{Name: "idx_products_title", Columns: []string{"title"}}},
+	}
+	categories := table{Name: "categories", Columns: []column{id, {Name: "slug", Type: "string", Unique: true}}}
+
+	// products dengan title di-rename menjadi name, ukuran kolom diubah,
+	// dan foreign key category_id ditambahkan
+	renamed := table{
+		Name: "products",
+		Columns: []column{
+			id,
+			{Name: "name", Type: "string", Size: "191"},
+			{Name: "price", Type: "decimal", Size: "10,2"},
+			{Name: "category_id", Type: "uint", Nullable: true, References: "categories", OnDelete: "SET NULL"},
+		},
+		Indexes: []index{{Name: "idx_products_name", Columns: []string{"name"}}},
+	}
+	renames := map[string]map[string]string{"products": {"title": "name"}}
+
+	// products dengan kolom stock yang boleh kosong
+	withStock := products
+	withStock.Columns = append(append([]column(nil), products.Columns...), column{Name: "stock", Type: "int", Nullable: true})
+
+	tests := []struct {
+		name     string
+		dialect  *sqlDialect
+		from, to []table
+		renames  map[string]map[string]string
+		want     []string
+	}{
+		{
+			name:    "tanpa perubahan",
+			dialect: postgresDialect,
+			from:    []table{products},
+			to:      []table{products},
+		},
+		{
+			name:    "create postgres",
+			dialect: postgresDialect,
+			to:      []table{categories},
+			want:    []string{"CREATE TABLE \"categories\" (\n    \"id\" BIGSERIAL PRIMARY KEY,\n    \"slug\" VARCHAR(255) NOT NULL UNIQUE\n)"},
+		},
+		{
+			name:    "drop",
+			dialect: mysqlDialect,
+			from:    []table{products, categories},
+			want:    []string{"DROP TABLE IF EXISTS `products`", "DROP TABLE IF EXISTS `categories`"},
+		},
+		{
+			name:    "alter postgres",
+			dialect: postgresDialect,
+			from:    []table{products},
+			to:      []table{categories, renamed},
+			renames: renames,
+			want: []string{
+				"CREATE TABLE \"categories\" (\n    \"id\" BIGSERIAL PRIMARY KEY,\n    \"slug\" VARCHAR(255) NOT NULL UNIQUE\n)",
+				`DROP INDEX "idx_products_title"`,
+				`ALTER TABLE "products" RENAME COLUMN "title" TO "name"`,
+				`ALTER TABLE "products" ADD COLUMN "category_id" BIGINT`,
+				`ALTER TABLE "products" ADD CONSTRAINT "fk_products_category_id" FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON DELETE SET NULL`,
+				`ALTER TABLE "products" ALTER COLUMN "name" TYPE VARCHAR(191) USING "name"::VARCHAR(191), ALTER COLUMN "name" SET NOT NULL, ALTER COLUMN "name" DROP DEFAULT`,
+				`ALTER TABLE "products" ALTER COLUMN "price" TYPE DECIMAL(10,2) USING "price"::DECIMAL(10,2), ALTER COLUMN "price" SET NOT NULL, ALTER COLUMN "price" DROP DEFAULT`,
+				`CREATE INDEX "idx_products_name" ON "products" ("name")`,
+			},
+		},
+		{
+			name:    "alter mysql",
+			dialect: mysqlDialect,
+			from:    []table{products},
+			to:      []table{renamed},
+			renames: renames,
+			want: []string{
+				"DROP INDEX `idx_products_title` ON `products`",
+				"ALTER TABLE `products` RENAME COLUMN `title` TO `name`",
+				"ALTER TABLE `products` ADD COLUMN `category_id` BIGINT UNSIGNED",
+				"ALTER TABLE `products` ADD CONSTRAINT `fk_products_category_id` FOREIGN KEY (`category_id`) REFERENCES `categories` (`id`) ON DELETE SET NULL",
+				"ALTER TABLE `products` MODIFY COLUMN `name` VARCHAR(191) NOT NULL",
+				"ALTER TABLE `products` MODIFY COLUMN `price` DECIMAL(10,2) NOT NULL",
+				"CREATE INDEX `idx_products_name` ON `products` (`name`)",
+			},
+		},
+		{
+			name:    "sqlite tambah kolom nullable",
+			dialect: sqliteDialect,
+			from:    []table{products},
+			to:      []table{withStock},
+			want:    []string{`ALTER TABLE "products" ADD COLUMN "stock" INTEGER`},
+		},
+		{
+			name:    "sqlite hapus kolom",
+			dialect: sqliteDialect,
+			from:    []table{withStock},
+			to:      []table{products},
+			want:    []string{`ALTER TABLE "products" DROP COLUMN "stock"`},
+		},
+		{
+			name:    "sqlite rebuild",
+			dialect: sqliteDialect,
+			from:    []table{products},
+			to:      []table{renamed},
+			renames: renames,
+			want: []string{
+				"CREATE TABLE \"products__capy_new\" (\n    \"id\" INTEGER PRIMARY KEY AUTOINCREMENT,\n    \"name\" TEXT NOT NULL,\n    \"price\" NUMERIC NOT NULL,\n" +
+					"    \"category_id\" INTEGER,\n    CONSTRAINT \"fk_products_category_id\" FOREIGN KEY (\"category_id\") REFERENCES \"categories\" (\"id\") ON DELETE SET NULL\n)",
+				`INSERT INTO "products__capy_new" ("id", "name", "price") SELECT "id", "title", "price" FROM "products"`,
+				`DROP TABLE "products"`,
+				`ALTER TABLE "products__capy_new" RENAME TO "products"`,
+				`CREATE INDEX "idx_products_name" ON "products" ("name")`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := schemaStatements(tt.dialect, tt.from, tt.to, tt.renames)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("schemaStatements =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
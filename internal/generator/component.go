@@ -2,16 +2,18 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
-	"text/template"
 )
 
 // ComponentGenerator bertanggung jawab untuk generate komponen
 type ComponentGenerator struct {
 	componentType string
 	componentName string
+	rootDir       string
+	modulePath    string
+	httpType      string
+	writer        *Writer
 }
 
 // NewComponentGenerator membuat instance baru ComponentGenerator
@@ -19,123 +21,78 @@ func NewComponentGenerator(componentType, componentName string) *ComponentGenera
 	return &ComponentGenerator{
 		componentType: componentType,
 		componentName: componentName,
+		writer:        NewWriter(),
 	}
 }
 
+// SetProject mengatur direktori root dan import path proyek tempat komponen ditulis
+func (g *ComponentGenerator) SetProject(project *Project) {
+	g.rootDir = project.Root
+	g.modulePath = project.ModulePath
+	g.httpType = project.HTTP
+}
+
+// SetWriter mengatur Writer yang dipakai Generate, mis. untuk mode dry-run
+func (g *ComponentGenerator) SetWriter(w *Writer) {
+	g.writer = w
+}
+
 // Generate membuat file komponen baru
 func (g *ComponentGenerator) Generate() error {
+	set := NewFileSet()
+	if err := g.Render(set); err != nil {
+		return err
+	}
+	return g.writer.Write(set)
+}
+
+// Render me-render file komponen ke dalam set tanpa menulis ke disk
+func (g *ComponentGenerator) Render(set *FileSet) error {
 	switch strings.ToLower(g.componentType) {
 	case "controller":
-		return g.generateController()
+		return g.generateController(set)
 	case "repository":
-		return g.generateRepository()
+		return g.generateRepository(set)
 	case "usecase":
-		return g.generateUsecase()
+		return g.generateUsecase(set)
 	default:
 		return fmt.Errorf("tipe komponen tidak valid: %s", g.componentType)
 	}
 }
 
-func (g *ComponentGenerator) generateController() error {
-	template := `package http
-
-import (
-	"net/http"
-)
-
-type {{.Name}}Handler struct {
-	usecase {{.Name}}Usecase
-}
-
-type {{.Name}}Usecase interface {
-	// TODO: Define usecase methods
-}
-
-func New{{.Name}}Handler(usecase {{.Name}}Usecase) *{{.Name}}Handler {
-	return &{{.Name}}Handler{
-		usecase: usecase,
-	}
-}
-
-func (h *{{.Name}}Handler) GetAll(w http.ResponseWriter, r *http.Request) {
-	// TODO: Implement handler
-}
-`
-	data := struct {
-		Name string
-	}{
-		Name: strings.Title(g.componentName),
-	}
-
-	return g.generateFile("internal/delivery/http", template, data)
-}
-
-func (g *ComponentGenerator) generateRepository() error {
-	template := `package repository
-
-type {{.Name}}Repository struct {
-	db interface{} // TODO: Replace with your database client
+func (g *ComponentGenerator) generateController(set *FileSet) error {
+	return g.generateFile(set, "internal/delivery/http", "component/controller.go.tmpl")
 }
 
-func New{{.Name}}Repository(db interface{}) *{{.Name}}Repository {
-	return &{{.Name}}Repository{
-		db: db,
-	}
+func (g *ComponentGenerator) generateRepository(set *FileSet) error {
+	return g.generateFile(set, "internal/repository", "component/repository.go.tmpl")
 }
 
-// TODO: Implement repository methods
-`
-	data := struct {
-		Name string
-	}{
-		Name: strings.Title(g.componentName),
-	}
-
-	return g.generateFile("internal/repository", template, data)
+func (g *ComponentGenerator) generateUsecase(set *FileSet) error {
+	return g.generateFile(set, "internal/usecase", "component/usecase.go.tmpl")
 }
 
-func (g *ComponentGenerator) generateUsecase() error {
-	template := `package usecase
-
-type {{.Name}}Usecase struct {
-	repo {{.Name}}Repository
-}
-
-type {{.Name}}Repository interface {
-	// TODO: Define repository methods
-}
-
-func New{{.Name}}Usecase(repo {{.Name}}Repository) *{{.Name}}Usecase {
-	return &{{.Name}}Usecase{
-		repo: repo,
+func (g *ComponentGenerator) generateFile(set *FileSet, dir, tmpl string) error {
+	profile, err := lookupHTTPProfile(g.httpType)
+	if err != nil {
+		return err
 	}
-}
-
-// TODO: Implement usecase methods
-`
 	data := struct {
 		Name string
+		HTTP *httpProfile
 	}{
-		Name: strings.Title(g.componentName),
-	}
-
-	return g.generateFile("internal/usecase", template, data)
-}
-
-func (g *ComponentGenerator) generateFile(dir, tmpl string, data interface{}) error {
-	t, err := template.New("component").Parse(tmpl)
-	if err != nil {
-		return fmt.Errorf("gagal parse template: %w", err)
+		Name: toPascal(g.componentName),
+		HTTP: profile,
 	}
 
 	filename := fmt.Sprintf("%s_%s.go", strings.ToLower(g.componentName), strings.ToLower(g.componentType))
-	path := filepath.Join(dir, filename)
+	path := filepath.Join(g.rootDir, dir, filename)
 
-	file, err := os.Create(path)
+	content, err := NewRenderer(g.rootDir, g.modulePath).RenderFile(tmpl, path, data)
 	if err != nil {
-		return fmt.Errorf("gagal membuat file: %w", err)
+		return err
 	}
-	defer file.Close()
 
-	return t.Execute(file, data)
+	set.Add(path, content)
+	return nil
 }
@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configFile adalah konfigurasi proyek yang dicatat capy new dan dibaca
+// kembali oleh perintah capy berikutnya
+const configFile = ".capy/config.yaml"
+
+// projectConfig adalah pilihan proyek yang tidak dapat diturunkan dari go.mod
+type projectConfig struct {
+	HTTP string `yaml:"http"` // framework HTTP, mis. gin
+}
+
+// loadConfig membaca konfigurasi proyek pada root. Proyek lama tanpa file
+// konfigurasi memakai nilai bawaan.
+func loadConfig(root string) (projectConfig, error) {
+	config := projectConfig{HTTP: DefaultHTTP}
+	path := filepath.Join(root, filepath.FromSlash(configFile))
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, fmt.Errorf("gagal membaca %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return config, fmt.Errorf("gagal parse %s: %w", path, err)
+	}
+	if _, err := lookupHTTPProfile(config.HTTP); err != nil {
+		return config, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
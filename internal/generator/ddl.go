@@ -0,0 +1,763 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ReadSQLSchema membaca statement CREATE TABLE, CREATE INDEX, dan ALTER TABLE
+// (dialect PostgreSQL dan MySQL) pada file DDL lalu memetakan tabel names ke
+// modul. Names kosong berarti semua tabel. Statement lain diabaikan.
+func ReadSQLSchema(path string, names []string) ([]ModuleSchema, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca %s: %w", path, err)
+	}
+
+	tables, err := parseDDL(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("gagal parse %s: %w", path, err)
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("tidak ada statement CREATE TABLE pada %s", path)
+	}
+
+	if len(names) > 0 {
+		selected := make([]*table, 0, len(names))
+		for _, name := range names {
+			t := findTable(tables, name)
+			if t == nil {
+				return nil, fmt.Errorf("tabel %s tidak ditemukan pada %s", name, path)
+			}
+			selected = append(selected, t)
+		}
+		tables = selected
+	}
+
+	tables = orderByDependencies(tables,
+		func(t *table) string { return t.Name },
+		func(t *table) []string {
+			var refs []string
+			for _, c := range t.Columns {
+				refs = append(refs, c.References)
+			}
+			return refs
+		})
+	schemas := make([]ModuleSchema, 0, len(tables))
+	for _, t := range tables {
+		schema, err := moduleFromTable(*t)
+		if err != nil {
+			return nil, err
+		}
+		// Tabel dari file DDL belum tentu ada di database, sehingga migration tetap dibuat
+		schema.Table.Exists = false
+		uintForeignKeys(&schema)
+		schemas = append(schemas, schema)
+	}
+	return schemas, nil
+}
+
+// uintForeignKeys mengubah field foreign key bertipe integer bertanda menjadi
+// uint, karena migration membuat kolom id tabel rujukan sebagai primary key
+// capy (BIGINT UNSIGNED pada MySQL) dan tipe kedua kolom harus sama
+func uintForeignKeys(schema *ModuleSchema) {
+	for i := range schema.Fields {
+		f := &schema.Fields[i]
+		if !f.ForeignKey || f.Type == "uint" {
+			continue
+		}
+		schema.Warnings = append(schema.Warnings, fmt.Sprintf("foreign key %s.%s bertipe %s dibuat sebagai uint agar cocok dengan kolom id tabel %s",
+			schema.Table.table(schema.Name), f.Column, f.Type, f.References()))
+		f.Type, f.GoType = "uint", fieldTypes["uint"].GoType
+		if f.Pointer() {
+			f.GoType = "*" + f.GoType
+		}
+	}
+}
+
+// orderByDependencies mengurutkan item sehingga item yang dirujuk (mis.
+// tabel tujuan foreign key) berada lebih dulu; urutan asal dipertahankan
+// selain itu. Item dengan rujukan melingkar tetap pada urutan aslinya.
+func orderByDependencies[T any](items []T, name func(T) string, deps func(T) []string) []T {
+	pending := make(map[string]bool, len(items))
+	for _, item := range items {
+		pending[name(item)] = true
+	}
+	resolved := func(item T) bool {
+		for _, dep := range deps(item) {
+			if dep != "" && dep != name(item) && pending[dep] {
+				return false
+			}
+		}
+		return true
+	}
+
+	sorted := make([]T, 0, len(items))
+	for len(sorted) < len(items) {
+		progress := false
+		for _, item := range items {
+			if !pending[name(item)] || !resolved(item) {
+				continue
+			}
+			sorted = append(sorted, item)
+			delete(pending, name(item))
+			progress = true
+		}
+		if !progress {
+			for _, item := range items {
+				if pending[name(item)] {
+					sorted = append(sorted, item)
+				}
+			}
+			break
+		}
+	}
+	return sorted
+}
+
+func findTable(tables []*table, name string) *table {
+	for _, t := range tables {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// sqlToken adalah satu token DDL
+type sqlToken struct {
+	kind byte // 'w' kata, 'q' identifier berkutip, 's' string, 'n' angka, 'p' tanda baca
+	text string
+	line int
+}
+
+// is membandingkan token kata atau tanda baca tanpa memperhatikan huruf besar
+func (t sqlToken) is(word string) bool {
+	return (t.kind == 'w' || t.kind == 'p') && strings.EqualFold(t.text, word)
+}
+
+// name mengembalikan identifier tanpa tanda kutip
+func (t sqlToken) name() string {
+	return t.text
+}
+
+// tokenizeSQL memecah DDL menjadi statement yang masing-masing berisi token
+func tokenizeSQL(src string) ([][]sqlToken, error) {
+	var (
+		stmts [][]sqlToken
+		cur   []sqlToken
+		line  = 1
+	)
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '-' && strings.HasPrefix(src[i:], "--"), c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '/' && strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("baris %d: komentar tidak ditutup", line)
+			}
+			line += strings.Count(src[i:i+2+end], "\n")
+			i += end + 4
+		case c == ';':
+			if len(cur) > 0 {
+				stmts = append(stmts, cur)
+				cur = nil
+			}
+			i++
+		case c == '\'' || c == '"' || c == '`':
+			j := i + 1
+			var b strings.Builder
+			for {
+				if j >= len(src) {
+					return nil, fmt.Errorf("baris %d: kutipan %c tidak ditutup", line, c)
+				}
+				if src[j] == c {
+					if j+1 < len(src) && src[j+1] == c {
+						b.WriteByte(c)
+						j += 2
+						continue
+					}
+					break
+				}
+				if src[j] == '\\' && c == '\'' && j+1 < len(src) {
+					b.WriteByte(src[j+1])
+					j += 2
+					continue
+				}
+				b.WriteByte(src[j])
+				j++
+			}
+			kind := byte('q')
+			if c == '\'' {
+				kind = 's'
+			}
+			cur = append(cur, sqlToken{kind: kind, text: b.String(), line: line})
+			line += strings.Count(src[i:j], "\n")
+			i = j + 1
+		case isSQLWordChar(c):
+			j := i
+			for j < len(src) && (isSQLWordChar(src[j]) || src[j] == '$') {
+				j++
+			}
+			kind := byte('w')
+			if c >= '0' && c <= '9' {
+				kind = 'n'
+				for j < len(src) && (src[j] == '.' || isSQLWordChar(src[j])) {
+					j++
+				}
+			}
+			cur = append(cur, sqlToken{kind: kind, text: src[i:j], line: line})
+			i = j
+		case c == '$' && dollarTag(src[i:]) != "":
+			// string dollar-quoted PostgreSQL, mis. badan function
+			tag := dollarTag(src[i:])
+			end := strings.Index(src[i+len(tag):], tag)
+			if end < 0 {
+				return nil, fmt.Errorf("baris %d: string %s tidak ditutup", line, tag)
+			}
+			body := src[i+len(tag) : i+len(tag)+end]
+			cur = append(cur, sqlToken{kind: 's', text: body, line: line})
+			line += strings.Count(body, "\n")
+			i += 2*len(tag) + end
+		case c == ':' && strings.HasPrefix(src[i:], "::"):
+			cur = append(cur, sqlToken{kind: 'p', text: "::", line: line})
+			i += 2
+		default:
+			cur = append(cur, sqlToken{kind: 'p', text: string(c), line: line})
+			i++
+		}
+	}
+	if len(cur) > 0 {
+		stmts = append(stmts, cur)
+	}
+	return stmts, nil
+}
+
+// dollarTag mengembalikan tag pembuka string dollar-quoted, mis. $$ atau
+// $body$, atau string kosong
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '$' {
+			return s[:i+1]
+		}
+		if !isSQLWordChar(s[i]) || s[i] >= '0' && s[i] <= '9' && i == 1 {
+			return ""
+		}
+	}
+	return ""
+}
+
+func isSQLWordChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// ddlParser mem-parse token satu statement
+type ddlParser struct {
+	toks []sqlToken
+	pos  int
+}
+
+func (p *ddlParser) peek() sqlToken {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return sqlToken{}
+}
+
+func (p *ddlParser) next() sqlToken {
+	t := p.peek()
+	if p.pos < len(p.toks) {
+		p.pos++
+	}
+	return t
+}
+
+func (p *ddlParser) done() bool {
+	return p.pos >= len(p.toks)
+}
+
+// accept melewati rangkaian kata jika cocok semuanya
+func (p *ddlParser) accept(words ...string) bool {
+	for i, w := range words {
+		if p.pos+i >= len(p.toks) || !p.toks[p.pos+i].is(w) {
+			return false
+		}
+	}
+	p.pos += len(words)
+	return true
+}
+
+func (p *ddlParser) expect(word string) error {
+	if !p.accept(word) {
+		return p.errorf("diharapkan %q", word)
+	}
+	return nil
+}
+
+func (p *ddlParser) errorf(format string, args ...interface{}) error {
+	t := p.peek()
+	at := "akhir statement"
+	if !p.done() {
+		at = fmt.Sprintf("baris %d dekat %q", t.line, t.text)
+	}
+	return fmt.Errorf("%s: %s", at, fmt.Sprintf(format, args...))
+}
+
+// ident membaca identifier, termasuk nama berkualifikasi schema.tabel
+// (hanya bagian terakhir yang dipakai)
+func (p *ddlParser) ident() (string, error) {
+	t := p.next()
+	if t.kind != 'w' && t.kind != 'q' {
+		p.pos--
+		return "", p.errorf("diharapkan nama")
+	}
+	name := t.name()
+	for p.peek().is(".") {
+		p.next()
+		t = p.next()
+		if t.kind != 'w' && t.kind != 'q' {
+			p.pos--
+			return "", p.errorf("diharapkan nama")
+		}
+		name = t.name()
+	}
+	return name, nil
+}
+
+// identList membaca daftar identifier dalam kurung, mis. (a, b). Panjang
+// prefix index MySQL seperti name(10) dan urutan ASC/DESC diabaikan.
+func (p *ddlParser) identList() ([]string, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var names []string
+	for {
+		name, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		if p.peek().is("(") {
+			p.skipGroup()
+		}
+		p.accept("ASC")
+		p.accept("DESC")
+		if p.accept(")") {
+			return names, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// skipGroup melewati token dalam kurung yang seimbang
+func (p *ddlParser) skipGroup() {
+	depth := 0
+	for !p.done() {
+		t := p.next()
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			depth--
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+// until mengumpulkan token sampai salah satu kata stop ditemukan di luar
+// kurung, atau sampai koma/kurung tutup yang mengakhiri definisi
+func (p *ddlParser) until(stop map[string]bool) []sqlToken {
+	start := p.pos
+	depth := 0
+	for !p.done() {
+		t := p.peek()
+		if depth == 0 && (t.is(",") || t.is(")") || t.kind == 'w' && stop[strings.ToUpper(t.text)]) {
+			break
+		}
+		if t.is("(") {
+			depth++
+		} else if t.is(")") {
+			depth--
+		}
+		p.next()
+	}
+	return p.toks[start:p.pos]
+}
+
+// joinTokens menyusun ulang token menjadi teks SQL
+func joinTokens(toks []sqlToken) string {
+	var b strings.Builder
+	for i, t := range toks {
+		text := t.text
+		if t.kind == 's' {
+			text = "'" + strings.ReplaceAll(text, "'", "''") + "'"
+		}
+		sign := i == 1 && (toks[0].is("-") || toks[0].is("+"))
+		glued := t.is("(") || t.is(")") || t.is(",") || t.is("::") || t.is("[") || t.is("]") ||
+			toks[max(i-1, 0)].is("(") || toks[max(i-1, 0)].is("::") || toks[max(i-1, 0)].is("[")
+		if i > 0 && !sign && !glued {
+			b.WriteByte(' ')
+		}
+		b.WriteString(text)
+	}
+	return b.String()
+}
+
+// columnStops adalah kata yang mengakhiri tipe atau nilai default kolom
+var columnStops = map[string]bool{
+	"NOT": true, "NULL": true, "DEFAULT": true, "PRIMARY": true, "UNIQUE": true,
+	"REFERENCES": true, "AUTO_INCREMENT": true, "AUTOINCREMENT": true, "CONSTRAINT": true,
+	"CHECK": true, "COLLATE": true, "COMMENT": true, "GENERATED": true, "ON": true,
+	"CHARSET": true,
+}
+
+// parseDDL mem-parse seluruh DDL menjadi tabel sesuai urutan CREATE TABLE
+func parseDDL(src string) ([]*table, error) {
+	stmts, err := tokenizeSQL(src)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []*table
+	byName := make(map[string]*table)
+	for _, toks := range stmts {
+		p := &ddlParser{toks: toks}
+		switch {
+		case p.accept("CREATE", "TABLE"), p.accept("CREATE", "UNLOGGED", "TABLE"):
+			t, err := p.createTable()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := byName[t.Name]; ok {
+				return nil, fmt.Errorf("tabel %s didefinisikan lebih dari sekali", t.Name)
+			}
+			tables = append(tables, t)
+			byName[t.Name] = t
+		case p.accept("CREATE", "INDEX"), p.accept("CREATE", "UNIQUE", "INDEX"):
+			unique := toks[1].is("UNIQUE")
+			if err := p.createIndex(byName, unique); err != nil {
+				return nil, err
+			}
+		case p.accept("ALTER", "TABLE"):
+			if err := p.alterTable(byName); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return tables, nil
+}
+
+// createTable mem-parse sisa statement CREATE TABLE
+func (p *ddlParser) createTable() (*table, error) {
+	p.accept("IF", "NOT", "EXISTS")
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	t := &table{Name: name}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	for {
+		if err := p.tableElement(t); err != nil {
+			return nil, fmt.Errorf("tabel %s: %w", name, err)
+		}
+		if p.accept(")") {
+			break
+		}
+		if err := p.expect(","); err != nil {
+			return nil, fmt.Errorf("tabel %s: %w", name, err)
+		}
+	}
+	if len(t.Columns) == 0 {
+		return nil, fmt.Errorf("tabel %s tidak memiliki kolom", name)
+	}
+	return t, nil
+}
+
+// tableElement mem-parse satu definisi kolom atau constraint tabel
+func (p *ddlParser) tableElement(t *table) error {
+	constraint := ""
+	if p.accept("CONSTRAINT") {
+		name, err := p.ident()
+		if err != nil {
+			return err
+		}
+		constraint = name
+	}
+
+	switch {
+	case p.accept("PRIMARY", "KEY"):
+		cols, err := p.identList()
+		if err != nil {
+			return err
+		}
+		for _, col := range cols {
+			if c := t.column(col); c != nil {
+				c.PrimaryKey = true
+				c.Nullable = false
+			}
+		}
+		p.until(nil)
+		return nil
+	case p.accept("FOREIGN", "KEY"):
+		return p.foreignKey(t)
+	case p.peek().is("UNIQUE"), p.peek().is("KEY"), p.peek().is("INDEX"):
+		unique := p.accept("UNIQUE")
+		if !p.accept("KEY") {
+			p.accept("INDEX")
+		}
+		if !p.peek().is("(") {
+			name, err := p.ident()
+			if err != nil {
+				return err
+			}
+			constraint = name
+		}
+		if p.accept("USING") {
+			p.next()
+		}
+		if !unique && constraint == "" {
+			return p.errorf("index tanpa nama")
+		}
+		cols, err := p.identList()
+		if err != nil {
+			return err
+		}
+		p.until(nil)
+		addParsedIndex(t, constraint, cols, unique)
+		return nil
+	case p.peek().is("CHECK"), p.peek().is("FULLTEXT"), p.peek().is("SPATIAL"), p.peek().is("EXCLUDE"):
+		p.until(nil)
+		return nil
+	}
+	if constraint != "" {
+		return p.errorf("constraint %s tidak dikenal", constraint)
+	}
+	return p.columnDef(t)
+}
+
+// columnDef mem-parse definisi kolom beserta constraint-nya
+func (p *ddlParser) columnDef(t *table) error {
+	name, err := p.ident()
+	if err != nil {
+		return err
+	}
+	typ := joinTokens(p.until(columnStops))
+	if typ == "" {
+		return p.errorf("tipe kolom %s tidak ditemukan", name)
+	}
+	c := column{Name: name, Nullable: true, sqlType: typ, Type: fieldTypeFromSQL(typ)}
+	c.Size = sqlTypeSize(c.Type, typ)
+
+	unique := false
+	for !p.done() && !p.peek().is(",") && !p.peek().is(")") {
+		switch {
+		case p.accept("NOT", "NULL"):
+			c.Nullable = false
+		case p.accept("NULL"):
+			c.Nullable = true
+		case p.accept("DEFAULT"):
+			c.Default, c.HasDefault = normalizeDefault(joinTokens(p.until(columnStops)))
+		case p.accept("PRIMARY", "KEY"):
+			c.PrimaryKey = true
+			c.Nullable = false
+		case p.accept("UNIQUE"):
+			p.accept("KEY")
+			unique = true
+		case p.accept("REFERENCES"):
+			ref, err := p.ident()
+			if err != nil {
+				return err
+			}
+			c.References = ref
+			if p.peek().is("(") {
+				p.skipGroup()
+			}
+			c.OnDelete, c.OnUpdate = p.referentialActions()
+		case p.accept("CONSTRAINT"):
+			if _, err := p.ident(); err != nil {
+				return err
+			}
+		case p.accept("ON", "UPDATE"):
+			// MySQL: ON UPDATE CURRENT_TIMESTAMP
+			p.until(columnStops)
+		case p.accept("GENERATED"):
+			// GENERATED {ALWAYS|BY DEFAULT} AS IDENTITY/ekspresi
+			p.accept("BY", "DEFAULT")
+			p.until(columnStops)
+		default:
+			// AUTO_INCREMENT, COLLATE, COMMENT, CHECK, GENERATED, dan lainnya
+			p.next()
+			p.until(columnStops)
+		}
+	}
+
+	if c.PrimaryKey {
+		c.HasDefault, c.Default = false, ""
+	}
+	t.Columns = append(t.Columns, c)
+	if unique {
+		addParsedIndex(t, "", []string{name}, true)
+	}
+	return nil
+}
+
+// foreignKey mem-parse FOREIGN KEY (kolom) REFERENCES tabel (kolom)
+func (p *ddlParser) foreignKey(t *table) error {
+	cols, err := p.identList()
+	if err != nil {
+		return err
+	}
+	if err := p.expect("REFERENCES"); err != nil {
+		return err
+	}
+	ref, err := p.ident()
+	if err != nil {
+		return err
+	}
+	if p.peek().is("(") {
+		p.skipGroup()
+	}
+	onDelete, onUpdate := p.referentialActions()
+	if len(cols) == 1 {
+		if c := t.column(cols[0]); c != nil {
+			c.References = ref
+			c.OnDelete, c.OnUpdate = onDelete, onUpdate
+		}
+	}
+	return nil
+}
+
+// referentialActions membaca aksi ON DELETE dan ON UPDATE foreign key lalu
+// melewati opsi foreign key lain
+func (p *ddlParser) referentialActions() (onDelete, onUpdate string) {
+	for {
+		switch {
+		case p.accept("ON", "DELETE"), p.accept("ON", "UPDATE"):
+			target := &onDelete
+			if p.toks[p.pos-1].is("UPDATE") {
+				target = &onUpdate
+			}
+			start := p.pos
+			switch {
+			case p.accept("SET", "NULL"), p.accept("SET", "DEFAULT"), p.accept("NO", "ACTION"):
+			default:
+				p.next()
+			}
+			*target = referentialAction(joinTokens(p.toks[start:p.pos]))
+		case p.accept("MATCH"), p.accept("DEFERRABLE"), p.accept("INITIALLY"):
+			if !p.peek().is(",") && !p.peek().is(")") && !p.done() {
+				p.next()
+			}
+		case p.accept("NOT", "DEFERRABLE"):
+		default:
+			return onDelete, onUpdate
+		}
+	}
+}
+
+// referentialAction menormalkan aksi foreign key, mis. cascade menjadi
+// CASCADE. NO ACTION adalah bawaan database sehingga dikembalikan kosong.
+func referentialAction(action string) string {
+	action = strings.ToUpper(strings.Join(strings.Fields(action), " "))
+	if action == "NO ACTION" {
+		return ""
+	}
+	return action
+}
+
+// createIndex mem-parse sisa statement CREATE [UNIQUE] INDEX
+func (p *ddlParser) createIndex(tables map[string]*table, unique bool) error {
+	p.accept("CONCURRENTLY")
+	p.accept("IF", "NOT", "EXISTS")
+	name := ""
+	if !p.peek().is("ON") {
+		n, err := p.ident()
+		if err != nil {
+			return err
+		}
+		name = n
+	}
+	if err := p.expect("ON"); err != nil {
+		return err
+	}
+	p.accept("ONLY")
+	tableName, err := p.ident()
+	if err != nil {
+		return err
+	}
+	if p.accept("USING") {
+		p.next()
+	}
+	cols, err := p.identList()
+	if err != nil {
+		// Index berbasis ekspresi tidak dapat dipetakan ke field
+		return nil
+	}
+	if t, ok := tables[tableName]; ok {
+		addParsedIndex(t, name, cols, unique)
+	}
+	return nil
+}
+
+// alterTable mem-parse ALTER TABLE ... ADD COLUMN dan ADD [CONSTRAINT nama]
+// PRIMARY KEY, UNIQUE, atau FOREIGN KEY seperti pada hasil pg_dump
+func (p *ddlParser) alterTable(tables map[string]*table) error {
+	p.accept("IF", "EXISTS")
+	p.accept("ONLY")
+	name, err := p.ident()
+	if err != nil {
+		return err
+	}
+	t, ok := tables[name]
+	if !ok {
+		return nil
+	}
+
+	for !p.done() {
+		if !p.accept("ADD") {
+			p.next()
+			continue
+		}
+		var err error
+		switch {
+		case p.accept("COLUMN"):
+			p.accept("IF", "NOT", "EXISTS")
+			err = p.columnDef(t)
+		case p.peek().is("CONSTRAINT"), p.peek().is("PRIMARY"), p.peek().is("UNIQUE"),
+			p.peek().is("FOREIGN"), p.peek().is("KEY"), p.peek().is("INDEX"):
+			err = p.tableElement(t)
+		}
+		if err != nil {
+			return fmt.Errorf("tabel %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// addParsedIndex menambahkan index hasil parsing; nama kosong berarti nama
+// bawaan GORM
+func addParsedIndex(t *table, name string, cols []string, unique bool) {
+	if name == "" {
+		name = indexName(t.Name, cols...)
+	}
+	for _, col := range cols {
+		t.addIndex(name, col, unique)
+	}
+}
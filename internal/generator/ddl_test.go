@@ -0,0 +1,265 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fieldSpec menuliskan field hasil impor dengan format mirip field spec,
+// mis. user_id:int64:fk=users
+func fieldSpec(f Field) string {
+	parts := []string{f.Column, f.Type}
+	if f.Size != "" {
+		parts = append(parts, "size="+f.Size)
+	}
+	if f.Nullable {
+		parts = append(parts, "null")
+	}
+	if f.Unique {
+		parts = append(parts, "unique")
+	}
+	if f.Index {
+		parts = append(parts, "index")
+	}
+	if f.IndexName != "" {
+		parts = append(parts, "index_name="+f.IndexName)
+	}
+	if f.ForeignKey {
+		parts = append(parts, "fk="+f.References())
+	}
+	if f.OnDelete != "" {
+		parts = append(parts, "ondelete="+f.OnDelete)
+	}
+	if f.OnUpdate != "" {
+		parts = append(parts, "onupdate="+f.OnUpdate)
+	}
+	if f.HasDefault {
+		parts = append(parts, "default="+f.Default)
+	}
+	if f.ReadOnly {
+		parts = append(parts, "readonly")
+	}
+	return strings.Join(parts, ":")
+}
+
+// importedModule adalah ringkasan ModuleSchema yang dibandingkan pada test
+type importedModule struct {
+	Name     string
+	Table    TableSpec
+	Fields   []string
+	Warnings []string
+}
+
+func summarizeModules(schemas []ModuleSchema) []importedModule {
+	var out []importedModule
+	for _, s := range schemas {
+		m := importedModule{Name: s.Name, Table: s.Table, Warnings: s.Warnings}
+		for _, f := range s.Fields {
+			m.Fields = append(m.Fields, fieldSpec(f))
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func TestReadSQLSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		ddl     string
+		tables  []string
+		want    []importedModule
+		wantErr string
+	}{
+		{
+			name: "postgres",
+			ddl: `-- hasil pg_dump
+CREATE TABLE public.users (
+    id bigint NOT NULL,
+    email character varying(191) NOT NULL,
+    active boolean DEFAULT true NOT NULL,
+    status character varying(20) DEFAULT 'draft'::character varying,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone
+);
+CREATE SEQUENCE public.users_id_seq;
+ALTER TABLE ONLY public.users ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.users ADD CONSTRAINT users_email_key UNIQUE (email);
+CREATE INDEX idx_users_status ON public.users USING btree (status);`,
+			want: []importedModule{{
+				Name:  "user",
+				Table: TableSpec{},
+				Fields: []string{
+					"email:string:size=191:unique:index_name=users_email_key",
+					"active:bool:default=true",
+					"status:string:size=20:null:index:default=draft",
+				},
+			}},
+		},
+		{
+			name: "mysql dan urutan foreign key",
+			ddl: "CREATE TABLE `posts` (\n" +
+				"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n" +
+				"  `author_id` bigint unsigned NOT NULL,\n" +
+				"  `title` varchar(255) NOT NULL COMMENT 'judul',\n" +
+				"  `geo` point,\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  KEY `idx_posts_author_id` (`author_id`),\n" +
+				"  UNIQUE KEY `uq_posts_title_author` (`title`, `author_id`),\n" +
+				"  CONSTRAINT `fk_posts_author` FOREIGN KEY (`author_id`) REFERENCES `authors` (`id`)\n" +
+				") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n" +
+				"CREATE TABLE `authors` (`id` int NOT NULL AUTO_INCREMENT PRIMARY KEY, `name` varchar(255) NOT NULL);",
+			want: []importedModule{
+				{
+					Name:   "author",
+					Table:  TableSpec{NoTimestamps: true},
+					Fields: []string{"name:string"},
+				},
+				{
+					Name:   "post",
+					Table:  TableSpec{NoTimestamps: true},
+					Fields: []string{"author_id:uint:index:fk=authors", "title:string"},
+					Warnings: []string{
+						"composite index uq_posts_title_author pada tabel posts dilewati",
+						"kolom posts.geo bertipe point tidak didukung dan dilewati",
+					},
+				},
+			},
+		},
+		{
+			name: "ukuran kolom dan aksi foreign key",
+			ddl: `CREATE TABLE users (id BIGINT PRIMARY KEY, email VARCHAR(191) NOT NULL, name VARCHAR(200) NOT NULL, balance NUMERIC(10, 2) NOT NULL, score DECIMAL(8));
+CREATE TABLE posts (
+    id BIGINT PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    editor_id BIGINT,
+    title VARCHAR(255) NOT NULL,
+    FOREIGN KEY (editor_id) REFERENCES users (id) ON DELETE SET NULL ON UPDATE NO ACTION
+);`,
+			want: []importedModule{
+				{
+					Name:   "user",
+					Table:  TableSpec{NoTimestamps: true},
+					Fields: []string{"email:string:size=191", "name:string:size=200", "balance:decimal:size=10,2", "score:decimal:size=8,0:null"},
+				},
+				{
+					Name:   "post",
+					Table:  TableSpec{NoTimestamps: true},
+					Fields: []string{"user_id:uint:fk=users:ondelete=CASCADE", "editor_id:uint:null:fk=users:ondelete=SET NULL", "title:string"},
+					Warnings: []string{
+						"foreign key posts.user_id bertipe int64 dibuat sebagai uint agar cocok dengan kolom id tabel users",
+						"foreign key posts.editor_id bertipe int64 dibuat sebagai uint agar cocok dengan kolom id tabel users",
+					},
+				},
+			},
+		},
+		{
+			name: "hanya created_at",
+			ddl:  "CREATE TABLE customers (id BIGINT PRIMARY KEY, name VARCHAR(100) NOT NULL, created_at TIMESTAMP NOT NULL);",
+			want: []importedModule{{
+				Name:   "customer",
+				Table:  TableSpec{NoTimestamps: true},
+				Fields: []string{"name:string:size=100", "created_at:time:readonly"},
+			}},
+		},
+		{
+			name:   "pilih tabel dengan nama tidak standar",
+			ddl:    "CREATE TABLE tbl_person (person_id INTEGER PRIMARY KEY, full_name TEXT); CREATE TABLE other (id INT PRIMARY KEY);",
+			tables: []string{"tbl_person"},
+			want: []importedModule{{
+				Name:   "tbl_person",
+				Table:  TableSpec{Name: "tbl_person", PrimaryKey: "person_id", NoTimestamps: true},
+				Fields: []string{"full_name:text:null"},
+			}},
+		},
+		{
+			name:    "tabel tidak ada",
+			ddl:     "CREATE TABLE users (id INT PRIMARY KEY);",
+			tables:  []string{"orders"},
+			wantErr: "tabel orders tidak ditemukan",
+		},
+		{
+			name:    "tanpa create table",
+			ddl:     "CREATE INDEX idx_x ON users (name);",
+			wantErr: "tidak ada statement CREATE TABLE",
+		},
+		{
+			name:    "composite primary key",
+			ddl:     "CREATE TABLE post_tags (post_id INT, tag_id INT, PRIMARY KEY (post_id, tag_id));",
+			wantErr: "tabel post_tags: composite primary key tidak didukung",
+		},
+		{
+			name:    "primary key bukan integer",
+			ddl:     "CREATE TABLE codes (code VARCHAR(10) PRIMARY KEY);",
+			wantErr: "primary key code harus bertipe integer",
+		},
+		{
+			name:    "tabel ganda",
+			ddl:     "CREATE TABLE users (id INT PRIMARY KEY); CREATE TABLE users (id INT PRIMARY KEY);",
+			wantErr: "tabel users didefinisikan lebih dari sekali",
+		},
+		{
+			name:    "kutipan tidak ditutup",
+			ddl:     "CREATE TABLE users (id INT PRIMARY KEY, name TEXT DEFAULT 'x);",
+			wantErr: "kutipan ' tidak ditutup",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "schema.sql")
+			if err := os.WriteFile(path, []byte(tt.ddl), 0644); err != nil {
+				t.Fatal(err)
+			}
+			schemas, err := ReadSQLSchema(path, tt.tables)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := summarizeModules(schemas); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("modul =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Migration dari modul hasil impor DDL harus mempertahankan definisi kolom
+// sumber dan tipe kolom yang sama dengan field entity
+func TestReadSQLSchemaMigration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.sql")
+	ddl := "CREATE TABLE users (id BIGINT PRIMARY KEY, email VARCHAR(191) NOT NULL);\n" +
+		"CREATE TABLE posts (id BIGINT PRIMARY KEY, user_id BIGINT NOT NULL, title VARCHAR(200) NOT NULL, " +
+		"FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE);\n" +
+		"CREATE TABLE customers (id BIGINT PRIMARY KEY, created_at TIMESTAMP NOT NULL);"
+	if err := os.WriteFile(path, []byte(ddl), 0644); err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := ReadSQLSchema(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, s := range schemas {
+		got = append(got, mysqlDialect.createTable(tableFromFields(s.Name, s.Table, s.Fields))...)
+	}
+	want := []string{
+		"CREATE TABLE `users` (\n    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,\n    `email` VARCHAR(191) NOT NULL\n)",
+		"CREATE TABLE `posts` (\n    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,\n    `user_id` BIGINT UNSIGNED NOT NULL,\n    `title` VARCHAR(200) NOT NULL,\n" +
+			"    CONSTRAINT `fk_posts_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE\n)",
+		"CREATE TABLE `customers` (\n    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,\n    `created_at` DATETIME(3) NOT NULL\n)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("migration =\n%q\nwant\n%q", got, want)
+	}
+	if f := schemas[1].Fields[0]; f.GoType != "uint" || f.GormTag() != "column:user_id;not null" {
+		t.Errorf("user_id GoType=%s tag=%q, want uint tanpa index", f.GoType, f.GormTag())
+	}
+}
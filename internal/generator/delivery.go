@@ -0,0 +1,43 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultDelivery adalah delivery modul yang dibuat tanpa --delivery
+const DefaultDelivery = "http"
+
+// Deliveries mengembalikan delivery modul yang didukung
+func Deliveries() []string {
+	return []string{"http", "grpc", "graphql"}
+}
+
+// deliveries adalah delivery yang melayani satu modul
+type deliveries struct {
+	HTTP    bool // handler REST pada internal/delivery/http
+	GRPC    bool // server gRPC pada internal/delivery/grpc
+	GraphQL bool // resolver GraphQL pada internal/delivery/graphql
+}
+
+// parseDelivery memeriksa daftar delivery modul; kosong berarti
+// DefaultDelivery
+func parseDelivery(names []string) (deliveries, error) {
+	var d deliveries
+	if len(names) == 0 {
+		names = []string{DefaultDelivery}
+	}
+	for _, name := range names {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "http":
+			d.HTTP = true
+		case "grpc":
+			d.GRPC = true
+		case "graphql":
+			d.GraphQL = true
+		default:
+			return d, fmt.Errorf("delivery tidak didukung: %s (pilihan: %s)", name, strings.Join(Deliveries(), ", "))
+		}
+	}
+	return d, nil
+}
@@ -0,0 +1,189 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sqlDialect menerjemahkan tabel ke DDL untuk satu jenis database
+type sqlDialect struct {
+	// types memetakan tipe pada field spec ke tipe kolom SQL
+	types map[string]string
+	// primaryKey adalah definisi lengkap kolom id; kolom foreign key bertipe
+	// uint memakai types["uint"] yang cocok dengan kolom ini
+	primaryKey string
+	// quote mengutip identifier
+	quote func(name string) string
+	// alterColumnFormat adalah pola statement perubahan definisi kolom
+	// dengan placeholder {table}, {column}, {type}, {definition}, {null}, dan
+	// {default}; kosong jika dialect tidak mendukungnya sehingga tabel
+	// dibuat ulang
+	alterColumnFormat string
+	// dropIndexFormat adalah pola statement penghapusan index
+	dropIndexFormat string
+	// dropForeignKey adalah klausa ALTER TABLE untuk menghapus foreign key
+	dropForeignKey string
+}
+
+var postgresDialect = &sqlDialect{
+	types: map[string]string{
+		"string":  "VARCHAR(255)",
+		"text":    "TEXT",
+		"int":     "BIGINT",
+		"int64":   "BIGINT",
+		"uint":    "BIGINT",
+		"float":   "DOUBLE PRECISION",
+		"decimal": "DECIMAL(12,2)",
+		"bool":    "BOOLEAN",
+		"time":    "TIMESTAMPTZ",
+		"date":    "DATE",
+	},
+	primaryKey: "BIGSERIAL PRIMARY KEY",
+	quote:      func(name string) string { return `"` + name + `"` },
+	alterColumnFormat: "ALTER TABLE {table} ALTER COLUMN {column} TYPE {type} USING {column}::{type}, " +
+		"ALTER COLUMN {column} {null}, ALTER COLUMN {column} {default}",
+	dropIndexFormat: "DROP INDEX {index}",
+	dropForeignKey:  "DROP CONSTRAINT",
+}
+
+var mysqlDialect = &sqlDialect{
+	types: map[string]string{
+		"string":  "VARCHAR(255)",
+		"text":    "TEXT",
+		"int":     "BIGINT",
+		"int64":   "BIGINT",
+		"uint":    "BIGINT UNSIGNED",
+		"float":   "DOUBLE",
+		"decimal": "DECIMAL(12,2)",
+		"bool":    "BOOLEAN",
+		"time":    "DATETIME(3)",
+		"date":    "DATE",
+	},
+	primaryKey:        "BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY",
+	quote:             func(name string) string { return "`" + name + "`" },
+	alterColumnFormat: "ALTER TABLE {table} MODIFY COLUMN {definition}",
+	dropIndexFormat:   "DROP INDEX {index} ON {table}",
+	dropForeignKey:    "DROP FOREIGN KEY",
+}
+
+var sqliteDialect = &sqlDialect{
+	types: map[string]string{
+		"string":  "TEXT",
+		"text":    "TEXT",
+		"int":     "INTEGER",
+		"int64":   "INTEGER",
+		"uint":    "INTEGER",
+		"float":   "REAL",
+		"decimal": "NUMERIC",
+		"bool":    "NUMERIC",
+		"time":    "DATETIME",
+		"date":    "DATE",
+	},
+	primaryKey:      "INTEGER PRIMARY KEY AUTOINCREMENT",
+	quote:           func(name string) string { return `"` + name + `"` },
+	dropIndexFormat: "DROP INDEX {index}",
+}
+
+// createTable menghasilkan statement CREATE TABLE beserta index-nya
+func (d *sqlDialect) createTable(t table) []string {
+	stmts := []string{d.createTableNamed(t, t.Name)}
+	for _, idx := range t.Indexes {
+		stmts = append(stmts, d.createIndex(t.Name, idx))
+	}
+	return stmts
+}
+
+// createTableNamed menghasilkan statement CREATE TABLE t tanpa index, dengan
+// nama constraint foreign key berdasarkan tabel constraintTable
+func (d *sqlDialect) createTableNamed(t table, constraintTable string) string {
+	var defs []string
+	for _, c := range t.Columns {
+		defs = append(defs, d.columnDef(c))
+	}
+	for _, c := range t.Columns {
+		if c.References != "" {
+			defs = append(defs, d.foreignKey(constraintTable, c))
+		}
+	}
+	return fmt.Sprintf("CREATE TABLE %s (\n    %s\n)", d.quote(t.Name), strings.Join(defs, ",\n    "))
+}
+
+// dropTable menghasilkan statement DROP TABLE
+func (d *sqlDialect) dropTable(name string) string {
+	return "DROP TABLE IF EXISTS " + d.quote(name)
+}
+
+// columnDef menghasilkan definisi kolom, mis. "price" DECIMAL(12,2) NOT NULL
+func (d *sqlDialect) columnDef(c column) string {
+	if c.PrimaryKey {
+		return d.quote(c.Name) + " " + d.primaryKey
+	}
+
+	def := d.quote(c.Name) + " " + d.columnType(c)
+	if !c.Nullable {
+		def += " NOT NULL"
+	}
+	if c.Unique {
+		def += " UNIQUE"
+	}
+	if c.HasDefault {
+		def += " DEFAULT " + sqlLiteral(c.Type, c.Default)
+	}
+	return def
+}
+
+// columnType mengembalikan tipe SQL kolom. Ukuran kolom menggantikan
+// parameter tipe bawaan, mis. VARCHAR(255) menjadi VARCHAR(191); tipe tanpa
+// parameter seperti TEXT pada SQLite tidak berubah.
+func (d *sqlDialect) columnType(c column) string {
+	typ := d.types[c.Type]
+	if base, _, ok := strings.Cut(typ, "("); ok && c.Size != "" {
+		typ = base + "(" + c.Size + ")"
+	}
+	return typ
+}
+
+func (d *sqlDialect) foreignKey(tableName string, c column) string {
+	def := fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		d.quote(foreignKeyName(tableName, c.Name)), d.quote(c.Name), d.quote(c.References), d.quote("id"))
+	if c.OnDelete != "" {
+		def += " ON DELETE " + c.OnDelete
+	}
+	if c.OnUpdate != "" {
+		def += " ON UPDATE " + c.OnUpdate
+	}
+	return def
+}
+
+func (d *sqlDialect) createIndex(tableName string, idx index) string {
+	kind := "INDEX"
+	if idx.Unique {
+		kind = "UNIQUE INDEX"
+	}
+	cols := make([]string, len(idx.Columns))
+	for i, c := range idx.Columns {
+		cols[i] = d.quote(c)
+	}
+	return fmt.Sprintf("CREATE %s %s ON %s (%s)", kind, d.quote(idx.Name), d.quote(tableName), strings.Join(cols, ", "))
+}
+
+// sqlLiteral mengubah nilai default pada field spec menjadi literal SQL.
+// Nilai untuk kolom teks dikutip, kecuali sudah dikutip oleh user.
+func sqlLiteral(typ, value string) string {
+	if strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") && len(value) > 1 {
+		return value
+	}
+	switch typ {
+	case "string", "text":
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	case "time", "date":
+		lower := strings.ToLower(value)
+		if lower == "now" || lower == "now()" || strings.HasPrefix(lower, "current_timestamp") {
+			return "CURRENT_TIMESTAMP"
+		}
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	case "bool":
+		return strings.ToUpper(value)
+	}
+	return value
+}
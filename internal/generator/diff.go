@@ -0,0 +1,161 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext adalah jumlah baris konteks di sekitar setiap perubahan
+const diffContext = 3
+
+// diffOp adalah satu baris hasil perbandingan: ' ' sama, '-' dihapus, '+' ditambah
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// UnifiedDiff menghasilkan unified diff antara oldContent dan newContent.
+// Hasilnya kosong jika kedua isi sama.
+func UnifiedDiff(oldName, newName string, oldContent, newContent []byte) string {
+	if string(oldContent) == string(newContent) {
+		return ""
+	}
+
+	ops := diffLines(splitLines(string(oldContent)), splitLines(string(newContent)))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+
+	for start := 0; start < len(ops); {
+		// Cari perubahan berikutnya
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+
+		// Perluas hunk selama jarak antar perubahan tidak lebih dari 2x konteks
+		end := start
+		for i := start; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				end = i + 1
+				continue
+			}
+			if i-end >= 2*diffContext {
+				break
+			}
+		}
+
+		from := max(start-diffContext, 0)
+		to := min(end+diffContext, len(ops))
+		writeHunk(&b, ops, from, to)
+		start = to
+	}
+
+	return b.String()
+}
+
+// writeHunk menulis satu hunk ops[from:to] beserta header @@
+func writeHunk(b *strings.Builder, ops []diffOp, from, to int) {
+	oldStart, newStart := 1, 1
+	for _, op := range ops[:from] {
+		if op.kind != '+' {
+			oldStart++
+		}
+		if op.kind != '-' {
+			newStart++
+		}
+	}
+
+	oldCount, newCount := 0, 0
+	for _, op := range ops[from:to] {
+		if op.kind != '+' {
+			oldCount++
+		}
+		if op.kind != '-' {
+			newCount++
+		}
+	}
+	if oldCount == 0 {
+		oldStart--
+	}
+	if newCount == 0 {
+		newStart--
+	}
+
+	fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+	for _, op := range ops[from:to] {
+		b.WriteByte(op.kind)
+		b.WriteString(op.line)
+		b.WriteByte('\n')
+	}
+}
+
+// diffLines membandingkan dua daftar baris menggunakan longest common subsequence
+func diffLines(a, b []string) []diffOp {
+	// Lewati prefix dan suffix yang sama agar tabel LCS tetap kecil
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	n, m := len(midA), len(midB)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case midA[i] == midB[j]:
+			ops = append(ops, diffOp{' ', midA[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', midA[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', midB[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{'-', midA[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{'+', midB[j]})
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// numberedLines membuat baris 1 sampai n, dengan baris pada changes diganti
+func numberedLines(n int, changes map[int]string) string {
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		line := strconv.Itoa(i)
+		if s, ok := changes[i]; ok {
+			line = s
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{name: "sama", old: "a\nb\n", new: "a\nb\n", want: ""},
+		{
+			name: "ubah baris",
+			old:  "a\nb\nc\n",
+			new:  "a\nB\nc\n",
+			want: "--- old\n+++ new\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name: "tambah di akhir",
+			old:  "a\nb\n",
+			new:  "a\nb\nc\n",
+			want: "--- old\n+++ new\n@@ -1,2 +1,3 @@\n a\n b\n+c\n",
+		},
+		{
+			name: "file baru",
+			old:  "",
+			new:  "x\ny\n",
+			want: "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+x\n+y\n",
+		},
+		{
+			name: "file dihapus",
+			old:  "x\ny\n",
+			new:  "",
+			want: "--- old\n+++ new\n@@ -1,2 +0,0 @@\n-x\n-y\n",
+		},
+		{
+			name: "konteks dibatasi",
+			old:  numberedLines(10, nil),
+			new:  numberedLines(10, map[int]string{10: "ten"}),
+			want: "--- old\n+++ new\n@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+ten\n",
+		},
+		{
+			name: "perubahan berdekatan satu hunk",
+			old:  numberedLines(10, nil),
+			new:  numberedLines(10, map[int]string{2: "two", 8: "eight"}),
+			want: "--- old\n+++ new\n@@ -1,10 +1,10 @@\n 1\n-2\n+two\n 3\n 4\n 5\n 6\n 7\n-8\n+eight\n 9\n 10\n",
+		},
+		{
+			name: "perubahan berjauhan dua hunk",
+			old:  numberedLines(20, nil),
+			new:  numberedLines(20, map[int]string{2: "two", 19: "nineteen"}),
+			want: "--- old\n+++ new\n@@ -1,5 +1,5 @@\n 1\n-2\n+two\n 3\n 4\n 5\n" +
+				"@@ -16,5 +16,5 @@\n 16\n 17\n 18\n-19\n+nineteen\n 20\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UnifiedDiff("old", "new", []byte(tt.old), []byte(tt.new))
+			if got != tt.want {
+				t.Errorf("UnifiedDiff =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
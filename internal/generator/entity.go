@@ -0,0 +1,261 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// entityTypes memetakan tipe Go pada struct entity ke tipe field spec
+var entityTypes = map[string]string{
+	"string":          "string",
+	"int":             "int",
+	"int8":            "int",
+	"int16":           "int",
+	"int32":           "int",
+	"int64":           "int64",
+	"uint":            "uint",
+	"uint8":           "uint",
+	"uint16":          "uint",
+	"uint32":          "uint",
+	"uint64":          "uint",
+	"float32":         "float",
+	"float64":         "float",
+	"bool":            "bool",
+	"time.Time":       "time",
+	"gorm.DeletedAt":  "time",
+	"sql.NullString":  "string",
+	"sql.NullInt64":   "int64",
+	"sql.NullInt32":   "int",
+	"sql.NullBool":    "bool",
+	"sql.NullFloat64": "float",
+	"sql.NullTime":    "time",
+}
+
+// gormTypes memetakan opsi type pada tag gorm ke tipe field spec
+var gormTypes = map[string]string{
+	"text":    "text",
+	"decimal": "decimal",
+	"numeric": "decimal",
+	"date":    "date",
+}
+
+// parseEntities membaca struct pada direktori entity dan membentuk tabel
+// sesuai konvensi GORM: nama tabel snake_case jamak (atau hasil method
+// TableName), nama kolom dari tag column atau snake_case nama field, serta
+// index dari tag index dan uniqueIndex. Field yang tipenya bukan kolom
+// (mis. relasi) diabaikan.
+func parseEntities(dir string) ([]table, error) {
+	fset := token.NewFileSet()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca direktori %s: %w", dir, err)
+	}
+
+	structs := make(map[string]*ast.StructType)
+	var names []string
+	tableNames := make(map[string]string)
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca %s: %w", path, err)
+		}
+		file, err := parser.ParseFile(fset, path, src, 0)
+		if err != nil {
+			return nil, fmt.Errorf("gagal parse %s: %w", displayPath(path), err)
+		}
+
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok || !ts.Name.IsExported() {
+						continue
+					}
+					if st, ok := ts.Type.(*ast.StructType); ok {
+						structs[ts.Name.Name] = st
+						names = append(names, ts.Name.Name)
+					}
+				}
+			case *ast.FuncDecl:
+				if recv, name, ok := tableNameMethod(decl); ok {
+					tableNames[recv] = name
+				}
+			}
+		}
+	}
+
+	sort.Strings(names)
+	tables := make([]table, 0, len(names))
+	for _, name := range names {
+		t := table{Name: tableName(name)}
+		if custom, ok := tableNames[name]; ok {
+			t.Name = custom
+		}
+		parseEntityFields(&t, structs[name].Fields.List)
+		if len(t.Columns) > 0 {
+			tables = append(tables, t)
+		}
+	}
+	return tables, nil
+}
+
+// parseEntityFields menambahkan kolom dan index dari field struct ke t
+func parseEntityFields(t *table, fields []*ast.Field) {
+	for _, field := range fields {
+		tag := gormTagOptions(field)
+		if _, ignored := tag["-"]; ignored {
+			continue
+		}
+
+		goType := entityTypeName(field.Type)
+		if len(field.Names) == 0 {
+			// Embedded gorm.Model menghasilkan kolom bawaan GORM
+			if goType == "gorm.Model" {
+				t.Columns = append(t.Columns,
+					column{Name: "id", Type: "uint", PrimaryKey: true},
+					column{Name: "created_at", Type: "time", Nullable: true},
+					column{Name: "updated_at", Type: "time", Nullable: true},
+					column{Name: "deleted_at", Type: "time", Nullable: true},
+				)
+				t.addIndex(indexName(t.Name, "deleted_at"), "deleted_at", false)
+			}
+			continue
+		}
+
+		typ, ok := entityTypes[goType]
+		if !ok {
+			continue
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			c := column{Name: toSnake(name.Name), Type: typ}
+			if v, ok := tag["column"]; ok && v != "" {
+				c.Name = v
+			}
+			if v, ok := tag["type"]; ok {
+				base, _, _ := strings.Cut(strings.ToLower(v), "(")
+				if typ, ok := gormTypes[base]; ok {
+					c.Type = typ
+				}
+				c.Size = sqlTypeSize(c.Type, v)
+			}
+			if v, ok := tag["size"]; ok && c.Type == "string" {
+				c.Size = columnSize(c.Type, v)
+			}
+			_, c.Unique = tag["unique"]
+			_, c.PrimaryKey = tag["primarykey"]
+			c.PrimaryKey = c.PrimaryKey || name.Name == "ID"
+			// GORM hanya menambahkan NOT NULL jika diminta lewat tag
+			_, notNull := tag["not null"]
+			c.Nullable = !c.PrimaryKey && !notNull
+			if v, ok := tag["default"]; ok {
+				c.Default = v
+				c.HasDefault = true
+			}
+			t.Columns = append(t.Columns, c)
+
+			if v, ok := tag["uniqueindex"]; ok {
+				t.addIndex(indexOrDefault(v, t.Name, c.Name), c.Name, true)
+			}
+			if v, ok := tag["index"]; ok {
+				t.addIndex(indexOrDefault(v, t.Name, c.Name), c.Name, false)
+			}
+			if goType == "gorm.DeletedAt" {
+				if _, ok := tag["index"]; !ok {
+					t.addIndex(indexName(t.Name, c.Name), c.Name, false)
+				}
+			}
+		}
+	}
+}
+
+// addIndex menambahkan kolom ke index bernama name; beberapa field dengan
+// nama index yang sama membentuk composite index
+func (t *table) addIndex(name, col string, unique bool) {
+	for i := range t.Indexes {
+		if t.Indexes[i].Name == name {
+			t.Indexes[i].Columns = append(t.Indexes[i].Columns, col)
+			return
+		}
+	}
+	t.Indexes = append(t.Indexes, index{Name: name, Columns: []string{col}, Unique: unique})
+}
+
+// indexOrDefault mengambil nama index dari nilai tag index/uniqueIndex,
+// mis. "idx_sku,sort:desc", atau nama bawaan GORM jika kosong
+func indexOrDefault(value, table, col string) string {
+	name, _, _ := strings.Cut(value, ",")
+	if name == "" {
+		return indexName(table, col)
+	}
+	return name
+}
+
+// gormTagOptions mem-parse tag gorm menjadi map opsi dengan kunci huruf kecil
+func gormTagOptions(field *ast.Field) map[string]string {
+	opts := make(map[string]string)
+	if field.Tag == nil {
+		return opts
+	}
+	raw, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return opts
+	}
+	for _, opt := range strings.Split(reflect.StructTag(raw).Get("gorm"), ";") {
+		key, value, _ := strings.Cut(opt, ":")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key != "" {
+			opts[key] = strings.TrimSpace(value)
+		}
+	}
+	return opts
+}
+
+// entityTypeName mengembalikan nama tipe field tanpa pointer, mis. time.Time
+func entityTypeName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.SelectorExpr:
+		if pkg, ok := expr.X.(*ast.Ident); ok {
+			return pkg.Name + "." + expr.Sel.Name
+		}
+	}
+	return ""
+}
+
+// tableNameMethod mengenali method TableName() string yang mengembalikan
+// string literal
+func tableNameMethod(fn *ast.FuncDecl) (recv, name string, ok bool) {
+	if fn.Recv == nil || len(fn.Recv.List) != 1 || fn.Name.Name != "TableName" || fn.Body == nil || len(fn.Body.List) != 1 {
+		return "", "", false
+	}
+	recv = entityTypeName(fn.Recv.List[0].Type)
+	ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return "", "", false
+	}
+	lit, ok := ret.Results[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", "", false
+	}
+	name, err := strconv.Unquote(lit.Value)
+	return recv, name, err == nil
+}
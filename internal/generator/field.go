@@ -0,0 +1,334 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jinzhu/inflection"
+)
+
+// fieldType mendeskripsikan tipe yang dapat dipakai pada field spec
+type fieldType struct {
+	GoType   string
+	GormType string
+}
+
+// fieldTypes memetakan nama tipe pada field spec ke tipe Go dan tipe kolom GORM
+var fieldTypes = map[string]fieldType{
+	"string":  {GoType: "string"},
+	"text":    {GoType: "string", GormType: "text"},
+	"int":     {GoType: "int"},
+	"int64":   {GoType: "int64"},
+	"uint":    {GoType: "uint"},
+	"float":   {GoType: "float64"},
+	"decimal": {GoType: "float64", GormType: "decimal(12,2)"},
+	"bool":    {GoType: "bool"},
+	"time":    {GoType: "time.Time"},
+	"date":    {GoType: "time.Time", GormType: "date"},
+}
+
+// Field merepresentasikan satu field modul hasil parsing field spec
+type Field struct {
+	Name       string // nama field Go, mis. CategoryID
+	Column     string // nama kolom database, mis. category_id
+	JSONName   string // nama properti JSON
+	Type       string // tipe pada field spec, mis. decimal
+	Size       string // panjang string atau presisi decimal, mis. 191 atau 10,2; kosong berarti bawaan
+	GoType     string // tipe Go, termasuk pointer untuk field nullable dan field ber-default
+	Nullable   bool
+	Unique     bool
+	Index      bool
+	IndexName  string // nama index jika berbeda dari nama bawaan GORM
+	NoIndex    bool   // tanpa index terpisah: unique berupa constraint kolom dan foreign key tidak di-index
+	ForeignKey bool
+	RefTable   string // tabel rujukan foreign key; kosong berarti dari nama kolom
+	OnDelete   string // aksi ON DELETE foreign key, mis. CASCADE; kosong berarti bawaan database
+	OnUpdate   string // aksi ON UPDATE foreign key
+	Default    string
+	HasDefault bool
+	ReadOnly   bool // diisi server, hanya muncul pada respons
+	WriteOnly  bool // hanya diterima pada request, mis. password
+	Hidden     bool // tidak pernah muncul pada request maupun respons
+
+	// Aturan validasi yang diperiksa usecase sebelum Create dan Update
+	Required bool
+	Email    bool
+	Min      string // panjang minimum untuk string, nilai minimum untuk angka
+	Max      string // panjang maksimum untuk string, nilai maksimum untuk angka
+	Pattern  string
+	Enum     []string
+}
+
+// ParseFields mengubah daftar field spec seperti "price:decimal" atau
+// "category_id:uint:fk" menjadi daftar Field
+func ParseFields(specs []string) ([]Field, error) {
+	fields := make([]Field, 0, len(specs))
+	seen := make(map[string]bool)
+
+	for _, spec := range specs {
+		field, err := ParseField(spec)
+		if err != nil {
+			return nil, err
+		}
+		if seen[field.Column] {
+			return nil, fmt.Errorf("field %s didefinisikan lebih dari sekali", field.Column)
+		}
+		seen[field.Column] = true
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// ParseField mengubah satu field spec dengan format nama:tipe[:modifier...]
+func ParseField(spec string) (Field, error) {
+	parts := strings.SplitN(spec, ":", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return Field{}, fmt.Errorf("field spec tidak valid: %q (format: nama:tipe[:modifier...])", spec)
+	}
+
+	typeName := strings.ToLower(parts[1])
+	ft, ok := fieldTypes[typeName]
+	if !ok {
+		return Field{}, fmt.Errorf("tipe field tidak dikenal pada %q: %s (pilihan: %s)", spec, parts[1], strings.Join(fieldTypeNames(), ", "))
+	}
+
+	column := toSnake(parts[0])
+	if isBaseColumn(column) {
+		return Field{}, fmt.Errorf("field %s sudah disediakan otomatis oleh capy", column)
+	}
+
+	field := Field{
+		Name:     toPascal(parts[0]),
+		Column:   column,
+		JSONName: column,
+		Type:     typeName,
+		GoType:   ft.GoType,
+	}
+
+	var mods []string
+	if len(parts) == 3 {
+		mods = splitModifiers(parts[2])
+	}
+	for _, mod := range mods {
+		key, value, hasValue := strings.Cut(mod, "=")
+		switch strings.ToLower(key) {
+		case "fk":
+			field.ForeignKey = true
+		case "unique":
+			field.Unique = true
+		case "index":
+			field.Index = true
+		case "null", "nullable":
+			field.Nullable = true
+		case "default":
+			if !hasValue {
+				return Field{}, fmt.Errorf("modifier default pada %q membutuhkan nilai, mis. default=true", spec)
+			}
+			field.Default = value
+			field.HasDefault = true
+		case "readonly":
+			field.ReadOnly = true
+		case "writeonly":
+			field.WriteOnly = true
+		case "hidden":
+			field.Hidden = true
+		default:
+			ok, err := field.setRule(strings.ToLower(key), value, hasValue)
+			if err != nil {
+				return Field{}, fmt.Errorf("field spec %q: %w", spec, err)
+			}
+			if !ok {
+				return Field{}, fmt.Errorf("modifier tidak dikenal pada %q: %s", spec, mod)
+			}
+		}
+	}
+
+	if field.ForeignKey && !strings.HasSuffix(field.Column, "_id") {
+		return Field{}, fmt.Errorf("field foreign key %s harus berakhiran _id", field.Column)
+	}
+	if field.ForeignKey && field.Type != "uint" {
+		return Field{}, fmt.Errorf("field foreign key %s harus bertipe uint agar cocok dengan kolom id tabel rujukan", field.Column)
+	}
+
+	if countTrue(field.ReadOnly, field.WriteOnly, field.Hidden) > 1 {
+		return Field{}, fmt.Errorf("modifier readonly, writeonly, dan hidden pada %q tidak dapat digabung", spec)
+	}
+
+	if err := field.checkRules(); err != nil {
+		return Field{}, fmt.Errorf("field spec %q: %w", spec, err)
+	}
+
+	if field.Pointer() {
+		field.GoType = "*" + field.GoType
+	}
+
+	return field, nil
+}
+
+// fieldModifiers adalah nama modifier pada field spec, termasuk aturan validasi
+var fieldModifiers = map[string]bool{
+	"fk": true, "unique": true, "index": true, "null": true, "nullable": true,
+	"default": true, "readonly": true, "writeonly": true, "hidden": true,
+	"required": true, "email": true, "min": true, "max": true, "regex": true, "enum": true,
+}
+
+// splitModifiers memisahkan daftar modifier pada ":". Bagian yang tidak
+// diawali nama modifier adalah lanjutan nilai modifier sebelumnya, sehingga
+// nilai seperti regex=^\d{2}:\d{2}$ atau default=08:00 tetap utuh.
+func splitModifiers(s string) []string {
+	var mods []string
+	for _, part := range strings.Split(s, ":") {
+		key, _, _ := strings.Cut(part, "=")
+		if n := len(mods); n > 0 && strings.Contains(mods[n-1], "=") && !fieldModifiers[strings.ToLower(key)] {
+			mods[n-1] += ":" + part
+			continue
+		}
+		mods = append(mods, part)
+	}
+	return mods
+}
+
+// GormTag menghasilkan isi tag gorm untuk field
+func (f Field) GormTag() string {
+	opts := []string{"column:" + f.Column}
+	if t := fieldTypes[f.Type].GormType; t != "" {
+		if f.Size != "" {
+			t, _, _ = strings.Cut(t, "(")
+			t += "(" + f.Size + ")"
+		}
+		opts = append(opts, "type:"+t)
+	} else if f.Size != "" {
+		opts = append(opts, "size:"+f.Size)
+	}
+	if !f.Nullable {
+		opts = append(opts, "not null")
+	}
+	index := ""
+	if f.IndexName != "" {
+		index = ":" + f.IndexName
+	}
+	switch {
+	case f.Unique && f.NoIndex:
+		opts = append(opts, "unique")
+	case f.Unique:
+		opts = append(opts, "uniqueIndex"+index)
+	case f.Index || f.ForeignKey && !f.NoIndex:
+		opts = append(opts, "index"+index)
+	}
+	if f.HasDefault {
+		opts = append(opts, "default:"+f.Default)
+	}
+	return strings.Join(opts, ";")
+}
+
+// Tag menghasilkan struct tag lengkap (json dan gorm) untuk field entity.
+// Field writeonly dan hidden tidak ikut di-encode ke JSON.
+func (f Field) Tag() string {
+	name := f.JSONName
+	if !f.InResponse() {
+		name = "-"
+	}
+	return fmt.Sprintf("json:%q gorm:%q", name, f.GormTag())
+}
+
+// JSONTag menghasilkan struct tag json untuk field pada DTO modul
+func (f Field) JSONTag() string {
+	return fmt.Sprintf("json:%q", f.JSONName)
+}
+
+// InRequest menandakan field yang dapat diisi client pada request
+// create dan update
+func (f Field) InRequest() bool {
+	return !f.ReadOnly && !f.Hidden
+}
+
+// Pointer menandakan field bertipe pointer pada entity. Selain field
+// nullable, field ber-default juga pointer karena GORM mengganti nilai kosong
+// (false, 0, "") dengan default kolom saat insert; nil berarti memakai default.
+func (f Field) Pointer() bool {
+	return f.Nullable || f.HasDefault
+}
+
+// Defaulted menandakan field ber-default yang tidak null. Nilai nil pada field
+// ini berarti tidak diisi client: create memakai default kolom dan update
+// mempertahankan nilai lama.
+func (f Field) Defaulted() bool {
+	return f.HasDefault && !f.Nullable
+}
+
+// UpdateGoType mengembalikan tipe field pada request update. Field
+// writeonly selalu pointer karena client tidak pernah menerima nilainya,
+// sehingga field yang tidak dikirim harus dapat dibedakan dari nilai kosong.
+func (f Field) UpdateGoType() string {
+	if f.WriteOnly && !f.Pointer() {
+		return "*" + f.GoType
+	}
+	return f.GoType
+}
+
+// NonZero mengembalikan ekspresi Go yang bernilai true jika nilai field v
+// tidak kosong, mis. v != ""
+func (f Field) NonZero(v string) string {
+	switch {
+	case f.Pointer():
+		return v + " != nil"
+	case f.IsTime():
+		return "!" + v + ".IsZero()"
+	case f.GoType == "string":
+		return v + ` != ""`
+	case f.GoType == "bool":
+		return v
+	}
+	return v + " != 0"
+}
+
+// InResponse menandakan field yang dikirim ke client pada respons
+func (f Field) InResponse() bool {
+	return !f.WriteOnly && !f.Hidden
+}
+
+// References mengembalikan nama tabel yang dirujuk oleh field foreign key
+func (f Field) References() string {
+	if !f.ForeignKey {
+		return ""
+	}
+	if f.RefTable != "" {
+		return f.RefTable
+	}
+	return inflection.Plural(strings.TrimSuffix(f.Column, "_id"))
+}
+
+// IsTime menandakan field bertipe time.Time
+func (f Field) IsTime() bool {
+	return fieldTypes[f.Type].GoType == "time.Time"
+}
+
+func countTrue(flags ...bool) int {
+	n := 0
+	for _, flag := range flags {
+		if flag {
+			n++
+		}
+	}
+	return n
+}
+
+// isBaseColumn menandakan kolom yang selalu dibuat oleh template entity
+func isBaseColumn(column string) bool {
+	switch column {
+	case "id", "created_at", "updated_at":
+		return true
+	}
+	return false
+}
+
+func fieldTypeNames() []string {
+	names := make([]string, 0, len(fieldTypes))
+	for name := range fieldTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,221 @@
+package generator
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestParseFieldRules(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Field
+		wantErr string // potongan pesan error; kosong berarti valid
+	}{
+		{spec: "name:string:required:min=2:max=100", want: Field{Required: true, Min: "2", Max: "100"}},
+		{spec: "email:string:email", want: Field{Email: true}},
+		{spec: "code:string:regex=^[A-Z]+$", want: Field{Pattern: "^[A-Z]+$"}},
+		{spec: "status:string:enum=draft|published", want: Field{Enum: []string{"draft", "published"}}},
+		{spec: "qty:int:min=-10:max=10", want: Field{Min: "-10", Max: "10"}},
+		{spec: "qty:uint:min=0:max=10", want: Field{Min: "0", Max: "10"}},
+		{spec: "level:uint:enum=1|2|3", want: Field{Enum: []string{"1", "2", "3"}}},
+		{spec: "price:decimal:min=0.5", want: Field{Min: "0.5"}},
+		{spec: "note:string:null:required", want: Field{Nullable: true, Required: true}},
+		{spec: `opens:string:regex=^\d{2}:\d{2}$`, want: Field{Pattern: `^\d{2}:\d{2}$`}},
+		{spec: "url:string:regex=^https?://:required:max=200", want: Field{Pattern: "^https?://", Required: true, Max: "200"}},
+		{spec: "slot:string:enum=08:00|09:30:unique", want: Field{Enum: []string{"08:00", "09:30"}}},
+
+		{spec: "qty:uint:min=-1", wantErr: `nilai "-1" bukan bilangan bulat tidak negatif`},
+		{spec: "qty:uint:max=-5", wantErr: `nilai "-5" bukan bilangan bulat tidak negatif`},
+		{spec: "level:uint:enum=1|-2", wantErr: `nilai "-2" bukan bilangan bulat tidak negatif`},
+		{spec: "name:string:min=-1", wantErr: `nilai "-1" bukan bilangan bulat tidak negatif`},
+		{spec: "name:string:max=1.5", wantErr: `nilai "1.5" bukan bilangan bulat tidak negatif`},
+		{spec: "qty:int:min=1.5", wantErr: `nilai "1.5" bukan bilangan bulat`},
+		{spec: "price:float:max=abc", wantErr: `nilai "abc" bukan angka`},
+		{spec: "level:int:enum=1|two", wantErr: `nilai "two" bukan bilangan bulat`},
+		{spec: "name:string:min", wantErr: "modifier min membutuhkan nilai"},
+		{spec: "name:string:enum=", wantErr: "modifier enum membutuhkan nilai"},
+		{spec: "code:string:regex=[", wantErr: "regex \"[\" tidak valid"},
+		{spec: "qty:int:email", wantErr: "modifier email dan regex hanya berlaku"},
+		{spec: "active:bool:min=1", wantErr: "modifier min dan max hanya berlaku"},
+		{spec: "active:bool:enum=true", wantErr: "modifier enum hanya berlaku"},
+		{spec: "active:bool:required", wantErr: "modifier required tidak berlaku untuk field bool"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseField(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseField(%q) error = %v, want %q", tt.spec, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseField(%q): %v", tt.spec, err)
+			}
+			rules := Field{
+				Nullable: got.Nullable, Required: got.Required, Email: got.Email,
+				Min: got.Min, Max: got.Max, Pattern: got.Pattern, Enum: got.Enum,
+			}
+			if !reflect.DeepEqual(rules, tt.want) {
+				t.Errorf("ParseField(%q) rules = %+v, want %+v", tt.spec, rules, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseField(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Field
+		tag     string // tag gorm yang diharapkan
+		wantErr string
+	}{
+		{
+			spec: "name:string",
+			want: Field{Name: "Name", Column: "name", JSONName: "name", Type: "string", GoType: "string"},
+			tag:  "column:name;not null",
+		},
+		{
+			spec: "unitPrice:Decimal:null",
+			want: Field{Name: "UnitPrice", Column: "unit_price", JSONName: "unit_price", Type: "decimal", GoType: "*float64", Nullable: true},
+			tag:  "column:unit_price;type:decimal(12,2)",
+		},
+		{
+			spec: "category_id:uint:fk",
+			want: Field{Name: "CategoryID", Column: "category_id", JSONName: "category_id", Type: "uint", GoType: "uint", ForeignKey: true},
+			tag:  "column:category_id;not null;index",
+		},
+		{
+			spec: "sku:string:unique:index",
+			want: Field{Name: "Sku", Column: "sku", JSONName: "sku", Type: "string", GoType: "string", Unique: true, Index: true},
+			tag:  "column:sku;not null;uniqueIndex",
+		},
+		{
+			spec: "published_at:time:nullable:index",
+			want: Field{Name: "PublishedAt", Column: "published_at", JSONName: "published_at", Type: "time", GoType: "*time.Time", Nullable: true, Index: true},
+			tag:  "column:published_at;index",
+		},
+		{
+			spec: "active:bool:default=true:readonly",
+			want: Field{Name: "Active", Column: "active", JSONName: "active", Type: "bool", GoType: "*bool", Default: "true", HasDefault: true, ReadOnly: true},
+			tag:  "column:active;not null;default:true",
+		},
+		{
+			spec: "opens_at:string:default=08:00:index",
+			want: Field{Name: "OpensAt", Column: "opens_at", JSONName: "opens_at", Type: "string", GoType: "*string", Index: true, Default: "08:00", HasDefault: true},
+			tag:  "column:opens_at;not null;index;default:08:00",
+		},
+		{
+			spec: "password:string:writeonly",
+			want: Field{Name: "Password", Column: "password", JSONName: "password", Type: "string", GoType: "string", WriteOnly: true},
+			tag:  "column:password;not null",
+		},
+		{
+			spec: "body:text:hidden",
+			want: Field{Name: "Body", Column: "body", JSONName: "body", Type: "text", GoType: "string", Hidden: true},
+			tag:  "column:body;type:text;not null",
+		},
+
+		{spec: "name", wantErr: "field spec tidak valid"},
+		{spec: ":string", wantErr: "field spec tidak valid"},
+		{spec: "name:varchar", wantErr: "tipe field tidak dikenal"},
+		{spec: "id:uint", wantErr: "field id sudah disediakan otomatis"},
+		{spec: "created_at:time", wantErr: "field created_at sudah disediakan otomatis"},
+		{spec: "category:uint:fk", wantErr: "field foreign key category harus berakhiran _id"},
+		{spec: "category_id:int64:fk", wantErr: "field foreign key category_id harus bertipe uint"},
+		{spec: "name:string:default", wantErr: "modifier default pada \"name:string:default\" membutuhkan nilai"},
+		{spec: "name:string:sorted", wantErr: "modifier tidak dikenal"},
+		{spec: "name:string:null:sorted:x", wantErr: "modifier tidak dikenal pada \"name:string:null:sorted:x\": sorted"},
+		{spec: "token:string:readonly:hidden", wantErr: "tidak dapat digabung"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseField(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseField(%q) error = %v, want %q", tt.spec, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseField(%q): %v", tt.spec, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseField(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+			if tag := got.GormTag(); tag != tt.tag {
+				t.Errorf("GormTag() = %q, want %q", tag, tt.tag)
+			}
+		})
+	}
+}
+
+func TestParseFieldsDuplicate(t *testing.T) {
+	if _, err := ParseFields([]string{"name:string", "Name:text"}); err == nil || !strings.Contains(err.Error(), "field name didefinisikan lebih dari sekali") {
+		t.Errorf("error = %v, want field didefinisikan lebih dari sekali", err)
+	}
+}
+
+// Field ber-default harus menyimpan nilai kosong (false, 0, "") yang dikirim
+// client; hanya field yang tidak diisi (nil) yang memakai default kolom
+func TestDefaultFieldStoresZeroValue(t *testing.T) {
+	fields, err := ParseFields([]string{"active:bool:default=true", "stock:int:default=5", "status:string:default=draft"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	goTypes := map[string]reflect.Type{
+		"*bool":   reflect.TypeOf((*bool)(nil)),
+		"*int":    reflect.TypeOf((*int)(nil)),
+		"*string": reflect.TypeOf((*string)(nil)),
+	}
+	// Struct entity dibentuk dari GoType dan tag field seperti template entity
+	structFields := []reflect.StructField{{Name: "ID", Type: reflect.TypeOf(uint(0)), Tag: `gorm:"primaryKey"`}}
+	for _, f := range fields {
+		typ, ok := goTypes[f.GoType]
+		if !ok {
+			t.Fatalf("GoType %s = %s, want pointer", f.Name, f.GoType)
+		}
+		structFields = append(structFields, reflect.StructField{Name: f.Name, Type: typ, Tag: reflect.StructTag(f.Tag())})
+	}
+	entityType := reflect.StructOf(structFields)
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "shop.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Table("products").AutoMigrate(reflect.New(entityType).Interface()); err != nil {
+		t.Fatal(err)
+	}
+
+	active, stock, status := false, 0, ""
+	zero := reflect.New(entityType)
+	zero.Elem().Field(1).Set(reflect.ValueOf(&active))
+	zero.Elem().Field(2).Set(reflect.ValueOf(&stock))
+	zero.Elem().Field(3).Set(reflect.ValueOf(&status))
+	for _, row := range []reflect.Value{zero, reflect.New(entityType)} {
+		if err := db.Table("products").Create(row.Interface()).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type product struct {
+		Active bool
+		Stock  int
+		Status string
+	}
+	var got []product
+	if err := db.Raw("SELECT active, stock, status FROM products ORDER BY id").Scan(&got).Error; err != nil {
+		t.Fatal(err)
+	}
+	want := []product{{false, 0, ""}, {true, 5, "draft"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("products = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,89 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// File adalah satu file hasil generate yang belum ditulis ke disk
+type File struct {
+	Path    string
+	Content []byte
+	Mode    os.FileMode
+	// Update menandakan suntingan atas file yang sudah ada (mis. main.go),
+	// yang isinya dihitung dari isi file saat ini sehingga boleh ditimpa
+	// tanpa resolusi konflik
+	Update bool
+	// Requires adalah path file lain pada FileSet yang harus ikut ditulis.
+	// Jika salah satunya dilewati, file ini juga dilewati, mis. snapshot
+	// skema yang hanya benar jika entity dan migration-nya ditulis.
+	Requires []string
+}
+
+// FileSet menampung direktori dan file hasil render sebelum ditulis ke disk,
+// sehingga seluruh hasil generate dapat diperiksa terlebih dahulu
+type FileSet struct {
+	dirs  []string
+	files []*File
+	index map[string]*File
+}
+
+// NewFileSet membuat FileSet kosong
+func NewFileSet() *FileSet {
+	return &FileSet{index: make(map[string]*File)}
+}
+
+// AddDir menambahkan direktori yang harus ada walaupun tidak berisi file
+func (s *FileSet) AddDir(dir string) {
+	s.dirs = append(s.dirs, filepath.Clean(dir))
+}
+
+// Add menambahkan file; file dengan path yang sama akan digantikan isinya
+func (s *FileSet) Add(path string, content []byte) {
+	path = filepath.Clean(path)
+	if f, ok := s.index[path]; ok {
+		f.Content = content
+		return
+	}
+	f := &File{Path: path, Content: content, Mode: 0644}
+	s.files = append(s.files, f)
+	s.index[path] = f
+}
+
+// Update menambahkan hasil suntingan file yang sudah ada di disk. Jika file
+// juga sedang di-generate pada FileSet yang sama, isinya saja yang diganti.
+func (s *FileSet) Update(path string, content []byte) {
+	path = filepath.Clean(path)
+	if f, ok := s.index[path]; ok {
+		f.Content = content
+		return
+	}
+	s.Add(path, content)
+	s.index[path].Update = true
+}
+
+// Require mencatat bahwa file pada path hanya ditulis jika seluruh file
+// paths juga ditulis. File paths harus ditambahkan sebelum file path.
+func (s *FileSet) Require(path string, paths ...string) {
+	if f, ok := s.index[filepath.Clean(path)]; ok {
+		for _, p := range paths {
+			f.Requires = append(f.Requires, filepath.Clean(p))
+		}
+	}
+}
+
+// Get mengembalikan file pada path jika sudah ada di FileSet
+func (s *FileSet) Get(path string) (*File, bool) {
+	f, ok := s.index[filepath.Clean(path)]
+	return f, ok
+}
+
+// Dirs mengembalikan direktori yang ditambahkan melalui AddDir
+func (s *FileSet) Dirs() []string {
+	return s.dirs
+}
+
+// Files mengembalikan file sesuai urutan penambahan
+func (s *FileSet) Files() []*File {
+	return s.files
+}
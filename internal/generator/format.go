@@ -0,0 +1,218 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// formatGo merapikan source Go hasil render seperti goimports: import yang
+// tidak dipakai dihapus, import dikelompokkan (standard library, pihak
+// ketiga, lalu package proyek dengan prefix localPrefix), lalu gofmt
+func formatGo(src []byte, localPrefix string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	if imports := file.Imports; len(imports) > 0 && !importsHaveComments(file) {
+		src = rewriteImports(fset, file, src, localPrefix)
+	}
+
+	return format.Source(src)
+}
+
+// importsHaveComments menandakan ada komentar di dalam blok import; blok
+// seperti itu tidak disusun ulang agar komentarnya tidak hilang
+func importsHaveComments(file *ast.File) bool {
+	for _, decl := range importDecls(file) {
+		for _, group := range file.Comments {
+			if group.Pos() >= decl.Pos() && group.End() <= decl.End() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rewriteImports mengganti semua deklarasi import dengan satu blok import
+// yang sudah dibersihkan dan dikelompokkan
+func rewriteImports(fset *token.FileSet, file *ast.File, src []byte, localPrefix string) []byte {
+	used := usedPackageNames(file)
+
+	var std, external, local []string
+	seen := make(map[string]bool)
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := ""
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+
+		switch name {
+		case "_", ".":
+		case "":
+			if !used[assumedPackageName(path)] {
+				continue
+			}
+		default:
+			if !used[name] {
+				continue
+			}
+		}
+
+		line := spec.Path.Value
+		if name != "" {
+			line = name + " " + line
+		}
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+
+		switch {
+		case localPrefix != "" && (path == localPrefix || strings.HasPrefix(path, localPrefix+"/")):
+			local = append(local, line)
+		case isStdlibPath(path):
+			std = append(std, line)
+		default:
+			external = append(external, line)
+		}
+	}
+
+	var block bytes.Buffer
+	var groups [][]string
+	for _, g := range [][]string{std, external, local} {
+		if len(g) > 0 {
+			sort.Slice(g, func(i, j int) bool { return importSortKey(g[i]) < importSortKey(g[j]) })
+			groups = append(groups, g)
+		}
+	}
+	if len(groups) > 0 {
+		block.WriteString("import (\n")
+		for i, g := range groups {
+			if i > 0 {
+				block.WriteString("\n")
+			}
+			for _, line := range g {
+				block.WriteString("\t" + line + "\n")
+			}
+		}
+		block.WriteString(")")
+	}
+
+	// Ganti deklarasi import pertama dengan blok baru dan hapus sisanya
+	decls := importDecls(file)
+	var out bytes.Buffer
+	last := 0
+	for i, decl := range decls {
+		start := fset.Position(decl.Pos()).Offset
+		end := fset.Position(decl.End()).Offset
+		out.Write(src[last:start])
+		if i == 0 {
+			out.Write(block.Bytes())
+		}
+		last = end
+	}
+	out.Write(src[last:])
+	return out.Bytes()
+}
+
+// importDecls mengembalikan semua deklarasi import pada file
+func importDecls(file *ast.File) []*ast.GenDecl {
+	var decls []*ast.GenDecl
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			decls = append(decls, gen)
+		}
+	}
+	return decls
+}
+
+// usedPackageNames mengumpulkan identifier yang dipakai sebagai qualifier
+// package, mis. "json" pada json.NewEncoder
+func usedPackageNames(file *ast.File) map[string]bool {
+	used := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if ident, ok := sel.X.(*ast.Ident); ok && ident.Obj == nil {
+			used[ident.Name] = true
+		}
+		return true
+	})
+	return used
+}
+
+// assumedPackageName menebak nama package dari import path dengan aturan
+// yang sama seperti goimports, mis. github.com/gofiber/fiber/v2 menjadi
+// fiber dan gopkg.in/yaml.v3 menjadi yaml
+func assumedPackageName(path string) string {
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && isMajorVersion(name) {
+		name = elems[len(elems)-2]
+	}
+	if i := strings.Index(name, ".v"); i > 0 {
+		name = name[:i]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	if i := strings.IndexFunc(name, func(r rune) bool {
+		return !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	_, err := strconv.Atoi(s[1:])
+	return err == nil
+}
+
+// isStdlibPath menandakan import path milik standard library, yaitu path
+// yang elemen pertamanya tidak mengandung titik
+func isStdlibPath(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// importSortKey mengurutkan import berdasarkan path, bukan alias
+func importSortKey(line string) string {
+	if i := strings.Index(line, `"`); i >= 0 {
+		return line[i:]
+	}
+	return line
+}
+
+// goSyntaxError menjelaskan kode Go hasil render yang tidak valid beserta
+// template dan baris asalnya
+func goSyntaxError(source, target string, src []byte, err error) error {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) || len(list) == 0 {
+		return fmt.Errorf("template %s menghasilkan kode Go yang tidak valid untuk %s: %w", source, displayPath(target), err)
+	}
+
+	first := list[0]
+	lines := strings.Split(string(src), "\n")
+	snippet := ""
+	if first.Pos.Line > 0 && first.Pos.Line <= len(lines) {
+		snippet = fmt.Sprintf("\n\t%4d | %s", first.Pos.Line, lines[first.Pos.Line-1])
+	}
+	return fmt.Errorf("template %s menghasilkan kode Go yang tidak valid untuk %s pada baris %d kolom %d: %s%s",
+		source, displayPath(target), first.Pos.Line, first.Pos.Column, first.Msg, snippet)
+}
@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatGo(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "hapus import tidak terpakai",
+			src:  "package x\nimport (\n\"fmt\"\n\"os\"\n)\nfunc f() { fmt.Println() }\n",
+			want: "package x\n\nimport (\n\t\"fmt\"\n)\n\nfunc f() { fmt.Println() }\n",
+		},
+		{
+			name: "kelompokkan import",
+			src: "package x\nimport \"example.com/shop/internal/entity\"\nimport (\n\"github.com/gofiber/fiber/v2\"\n\"strings\"\n)\n" +
+				"var _ = strings.TrimSpace\nvar _ = fiber.New\nvar _ entity.Product\n",
+			want: "package x\n\nimport (\n\t\"strings\"\n\n\t\"github.com/gofiber/fiber/v2\"\n\n\t\"example.com/shop/internal/entity\"\n)\n\n" +
+				"var _ = strings.TrimSpace\nvar _ = fiber.New\nvar _ entity.Product\n",
+		},
+		{
+			name: "alias dan blank import",
+			src:  "package x\nimport (\n_ \"github.com/lib/pq\"\nyaml \"gopkg.in/yaml.v3\"\nj \"encoding/json\"\n\"fmt\"\n\"fmt\"\n)\nvar _ = yaml.Marshal\nvar _ = fmt.Sprint\n",
+			want: "package x\n\nimport (\n\t\"fmt\"\n\n\t_ \"github.com/lib/pq\"\n\tyaml \"gopkg.in/yaml.v3\"\n)\n\nvar _ = yaml.Marshal\nvar _ = fmt.Sprint\n",
+		},
+		{
+			name: "blok import berkomentar tidak diubah",
+			src:  "package x\nimport (\n// driver\n\"os\"\n)\n",
+			want: "package x\n\nimport (\n\t// driver\n\t\"os\"\n)\n",
+		},
+		{
+			name: "semua import tidak terpakai",
+			src:  "package x\nimport \"os\"\nvar n = 1\n",
+			want: "package x\n\nvar n = 1\n",
+		},
+		{
+			name:    "sintaks tidak valid",
+			src:     "package x\nfunc f() {\n",
+			wantErr: "expected '}'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatGo([]byte(tt.src), "example.com/shop")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("formatGo =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
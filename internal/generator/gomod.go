@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/mod/modfile"
+)
+
+// Project menyimpan informasi proyek Go tempat capy dijalankan
+type Project struct {
+	Root       string // direktori yang berisi go.mod
+	ModulePath string // path modul pada baris module di go.mod
+	Database   string // database dari driver GORM pada go.mod, mis. postgres
+	HTTP       string // framework HTTP dari .capy/config.yaml, mis. gin
+}
+
+// DetectProject mencari go.mod mulai dari dir lalu naik ke direktori induk,
+// kemudian membaca path modul dan driver database dari file tersebut serta
+// framework HTTP dari konfigurasi proyek
+func DetectProject(dir string) (*Project, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca direktori %s: %w", dir, err)
+	}
+
+	for {
+		path := filepath.Join(dir, "go.mod")
+		content, err := os.ReadFile(path)
+		if err == nil {
+			f, err := modfile.ParseLax(path, content, nil)
+			if err != nil {
+				return nil, fmt.Errorf("gagal parse %s: %w", path, err)
+			}
+			if f.Module == nil || f.Module.Mod.Path == "" {
+				return nil, fmt.Errorf("baris module tidak ditemukan pada %s", path)
+			}
+			config, err := loadConfig(dir)
+			if err != nil {
+				return nil, err
+			}
+			return &Project{Root: dir, ModulePath: f.Module.Mod.Path, Database: detectDatabase(f.Require), HTTP: config.HTTP}, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("gagal membaca %s: %w", path, err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, errors.New("go.mod tidak ditemukan, jalankan capy dari dalam direktori proyek Go")
+		}
+		dir = parent
+	}
+}
+
+// addRequires menambahkan dependensi mods yang belum ada ke go.mod
+// proyek; go.sum dilengkapi dengan go mod tidy
+func (g *ModuleGenerator) addRequires(set *FileSet, mods []module) error {
+	modPath := filepath.Join(g.rootDir, "go.mod")
+	src, ok, err := currentContent(set, modPath)
+	if err != nil || !ok {
+		return err
+	}
+	f, err := modfile.Parse(modPath, src, nil)
+	if err != nil {
+		return fmt.Errorf("gagal parse go.mod: %w", err)
+	}
+
+	changed := false
+	for _, req := range mods {
+		found := false
+		for _, r := range f.Require {
+			found = found || r.Mod.Path == req.Path
+		}
+		if !found {
+			if err := f.AddRequire(req.Path, req.Version); err != nil {
+				return fmt.Errorf("gagal menambahkan %s ke go.mod: %w", req.Path, err)
+			}
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	f.Cleanup()
+	content, err := f.Format()
+	if err != nil {
+		return fmt.Errorf("gagal menulis go.mod: %w", err)
+	}
+	set.Update(modPath, content)
+	return nil
+}
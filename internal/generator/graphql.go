@@ -0,0 +1,231 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+
+	"github.com/jinzhu/inflection"
+)
+
+const (
+	graphqlDir       = "internal/delivery/graphql" // resolver GraphQL modul
+	graphqlSchemaDir = graphqlDir + "/schema"      // skema GraphQL per modul
+)
+
+// graphqlRequires adalah dependensi yang ditambahkan ke go.mod proyek saat
+// modul pertama dengan delivery GraphQL dibuat
+var graphqlRequires = []module{
+	{"github.com/graph-gophers/graphql-go", "v1.5.0"},
+}
+
+// graphqlSharedFiles adalah file bersama resolver GraphQL seluruh modul
+var graphqlSharedFiles = []struct{ path, tmpl string }{
+	{graphqlDir + "/graphql.go", "graphql/graphql.go.tmpl"},
+	{graphqlDir + "/resolver.go", "graphql/resolver.go.tmpl"},
+	{graphqlDir + "/errors.go", "graphql/errors.go.tmpl"},
+	{graphqlDir + "/convert.go", "graphql/convert.go.tmpl"},
+	{graphqlSchemaDir + "/schema.graphql", "graphql/schema.graphql.tmpl"},
+}
+
+// graphqlTypes memetakan tipe field spec ke tipe GraphQL beserta tipe Go
+// nilainya pada resolver
+var graphqlTypes = map[string]struct{ GraphQL, Go string }{
+	"string":  {"String", "string"},
+	"text":    {"String", "string"},
+	"int":     {"Int", "int32"},
+	"int64":   {"Int64", "Int64"},
+	"uint":    {"Int", "int32"},
+	"float":   {"Float", "float64"},
+	"decimal": {"Float", "float64"},
+	"bool":    {"Boolean", "bool"},
+	"time":    {"Time", "graphql.Time"},
+	"date":    {"Time", "graphql.Time"},
+}
+
+// graphqlField adalah field modul pada type dan input GraphQL
+type graphqlField struct {
+	Field
+	GraphQLName string // nama field GraphQL, mis. categoryId
+	// Optional menandakan field nullable pada input walaupun field entity
+	// tidak nullable, mis. field writeonly pada input update
+	Optional bool
+}
+
+// nullable menandakan field boleh kosong pada skema
+func (f graphqlField) nullable() bool {
+	return f.Pointer() || f.Optional
+}
+
+// GraphQLType menulis tipe field pada skema, mis. String! atau Int
+func (f graphqlField) GraphQLType() string {
+	t := graphqlTypes[f.Type].GraphQL
+	if !f.nullable() {
+		t += "!"
+	}
+	return t
+}
+
+// ResolverType mengembalikan tipe Go field pada struct resolver
+func (f graphqlField) ResolverType() string {
+	t := graphqlTypes[f.Type].Go
+	if f.nullable() {
+		t = "*" + t
+	}
+	return t
+}
+
+// ToGraphQL mengembalikan ekspresi nilai field GraphQL dari entity v
+func (f graphqlField) ToGraphQL(v string) string {
+	value := v + "." + f.Name
+	goType, gqlType := fieldTypes[f.Type].GoType, graphqlTypes[f.Type].Go
+	switch {
+	case f.IsTime() && f.Pointer():
+		return "toTime(" + value + ")"
+	case f.IsTime():
+		return "graphql.Time{Time: " + value + "}"
+	case goType != gqlType && f.Pointer():
+		return "convertPtr[" + gqlType + "](" + value + ")"
+	case goType != gqlType:
+		return gqlType + "(" + value + ")"
+	}
+	return value
+}
+
+// FromGraphQL mengembalikan ekspresi nilai field entity dari input in.
+// Field Optional dibaca lewat pointer sehingga hanya boleh dipakai setelah
+// dicek tidak nil.
+func (f graphqlField) FromGraphQL(in string) string {
+	value := in + "." + f.Name
+	goType, gqlType := fieldTypes[f.Type].GoType, graphqlTypes[f.Type].Go
+	if f.Optional && !f.Pointer() && !f.IsTime() {
+		value = "*" + value
+	}
+	switch {
+	case f.IsTime() && f.Pointer():
+		return "fromTime(" + value + ")"
+	case f.IsTime():
+		return value + ".Time"
+	case goType != gqlType && f.Pointer():
+		return "convertPtr[" + goType + "](" + value + ")"
+	case goType != gqlType:
+		return goType + "(" + value + ")"
+	}
+	return value
+}
+
+// graphqlData adalah data template skema dan resolver GraphQL modul
+type graphqlData struct {
+	moduleData
+	Object []graphqlField // field type entity
+	Input  []graphqlField // field input create
+	// UpdateInput adalah field input update; field writeonly nullable
+	// karena client tidak pernah menerima nilainya
+	UpdateInput []graphqlField
+}
+
+// ItemQuery mengembalikan nama query satu entity, mis. product
+func (d graphqlData) ItemQuery() string {
+	return toCamel(d.Name)
+}
+
+// ListQuery mengembalikan nama query list entity, mis. products. Nama
+// yang bentuk jamaknya sama memakai akhiran List.
+func (d graphqlData) ListQuery() string {
+	if plural := inflection.Plural(d.ItemQuery()); plural != d.ItemQuery() {
+		return plural
+	}
+	return d.ItemQuery() + "List"
+}
+
+// ListMethod mengembalikan nama method resolver query list, mis. Products
+func (d graphqlData) ListMethod() string {
+	return toPascal(d.ListQuery())
+}
+
+// generateGraphQL membuat skema GraphQL modul dan resolver yang memanggil
+// usecase modul, serta file bersama endpoint /graphql dan playground
+func (g *ModuleGenerator) generateGraphQL(set *FileSet) error {
+	data := graphqlData{moduleData: g.templateData()}
+	for _, f := range data.ResponseFields() {
+		if _, ok := graphqlTypes[f.Type]; !ok {
+			return fmt.Errorf("tipe %s pada field %s belum didukung delivery GraphQL", f.Type, f.Name)
+		}
+		data.Object = append(data.Object, graphqlField{Field: f, GraphQLName: jsonCamelCase(toSnake(f.Name))})
+	}
+	for _, f := range data.RequestFields() {
+		if _, ok := graphqlTypes[f.Type]; !ok {
+			return fmt.Errorf("tipe %s pada field %s belum didukung delivery GraphQL", f.Type, f.Name)
+		}
+		data.Input = append(data.Input, graphqlField{Field: f, GraphQLName: jsonCamelCase(toSnake(f.Name))})
+		data.UpdateInput = append(data.UpdateInput, graphqlField{Field: f, GraphQLName: jsonCamelCase(toSnake(f.Name)), Optional: f.WriteOnly})
+	}
+
+	renderer := NewRenderer(g.rootDir, g.modulePath)
+	render := func(dir, name, tmpl string, data interface{}) error {
+		target := filepath.Join(g.rootDir, filepath.FromSlash(dir), name)
+		content, err := renderer.RenderFile(tmpl, target, data)
+		if err != nil {
+			return err
+		}
+		set.Add(target, content)
+		return nil
+	}
+
+	for _, f := range graphqlSharedFiles {
+		target := filepath.Join(g.rootDir, filepath.FromSlash(f.path))
+		if _, exists, err := currentContent(set, target); err != nil {
+			return err
+		} else if exists {
+			continue
+		}
+		dir, name := filepath.Split(filepath.FromSlash(f.path))
+		if err := render(dir, name, f.tmpl, data); err != nil {
+			return err
+		}
+	}
+
+	if err := render(graphqlSchemaDir, g.moduleName+".graphql", "graphql/module.graphql.tmpl", data); err != nil {
+		return err
+	}
+	if err := render(graphqlDir, g.moduleName+"_resolver.go", "graphql/module_resolver.go.tmpl", data); err != nil {
+		return err
+	}
+
+	if err := g.addRequires(set, graphqlRequires); err != nil {
+		return err
+	}
+
+	// Resolver modul menjadi embedded field root Resolver
+	r := &registrar{set: set, rootDir: g.rootDir, modulePath: g.modulePath, name: data.Name}
+	return r.edit(filepath.Join(filepath.FromSlash(graphqlDir), "resolver.go"), r.addResolver)
+}
+
+// addResolver menambahkan *NameResolver sebagai embedded field struct
+// Resolver sehingga query dan mutation modul menjadi method root resolver
+func (r *registrar) addResolver(fset *token.FileSet, file *ast.File) ([]textEdit, error) {
+	field := r.name + "Resolver"
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok || ts.Name.Name != "Resolver" {
+				continue
+			}
+			for _, f := range st.Fields.List {
+				if star, ok := f.Type.(*ast.StarExpr); ok && len(f.Names) == 0 && isIdent(star.X, field) {
+					return nil, nil
+				}
+			}
+			offset := fset.Position(st.Fields.Closing).Offset
+			return []textEdit{{offset, offset, "\t*" + field + "\n"}}, nil
+		}
+	}
+	return nil, errors.New("struct Resolver tidak ditemukan")
+}
@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestGraphQLFieldUpdateInput(t *testing.T) {
+	tests := []struct {
+		spec         string
+		graphqlType  string
+		resolverType string
+		fromGraphQL  string
+	}{
+		{spec: "password:string:writeonly", graphqlType: "String", resolverType: "*string", fromGraphQL: "*in.Password"},
+		{spec: "pin:int:writeonly", graphqlType: "Int", resolverType: "*int32", fromGraphQL: "int(*in.Pin)"},
+		{spec: "pin:int:writeonly:null", graphqlType: "Int", resolverType: "*int32", fromGraphQL: "convertPtr[int](in.Pin)"},
+		{spec: "joined:time:writeonly", graphqlType: "Time", resolverType: "*graphql.Time", fromGraphQL: "in.Joined.Time"},
+		{spec: "name:string", graphqlType: "String!", resolverType: "string", fromGraphQL: "in.Name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			field, err := ParseField(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f := graphqlField{Field: field, Optional: field.WriteOnly}
+			if got := f.GraphQLType(); got != tt.graphqlType {
+				t.Errorf("GraphQLType = %q, want %q", got, tt.graphqlType)
+			}
+			if got := f.ResolverType(); got != tt.resolverType {
+				t.Errorf("ResolverType = %q, want %q", got, tt.resolverType)
+			}
+			if got := f.FromGraphQL("in"); got != tt.fromGraphQL {
+				t.Errorf("FromGraphQL = %q, want %q", got, tt.fromGraphQL)
+			}
+		})
+	}
+}
@@ -0,0 +1,598 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/jinzhu/inflection"
+	"google.golang.org/protobuf/cmd/protoc-gen-go/internal_gengo"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const (
+	protoDir  = "api/proto"              // file .proto modul
+	grpcDir   = "internal/delivery/grpc" // server gRPC modul
+	grpcPBDir = grpcDir + "/pb"          // kode Go hasil generate dari .proto
+	// paginationProto berisi message metadata pagination yang dipakai
+	// respons list setiap modul
+	paginationProto = "pagination.proto"
+)
+
+// grpcRequires adalah dependensi yang ditambahkan ke go.mod proyek saat
+// modul pertama dengan delivery gRPC dibuat
+var grpcRequires = []module{
+	{"google.golang.org/grpc", "v1.64.0"},
+	{"google.golang.org/protobuf", "v1.34.1"},
+}
+
+// grpcSharedFiles adalah file bersama server gRPC seluruh modul
+var grpcSharedFiles = []struct{ path, tmpl string }{
+	{grpcDir + "/errors.go", "grpc/errors.go.tmpl"},
+	{grpcDir + "/convert.go", "grpc/convert.go.tmpl"},
+}
+
+// protoScalars memetakan tipe field spec ke tipe scalar protobuf. Tipe
+// waktu memakai google.protobuf.Timestamp.
+var protoScalars = map[string]string{
+	"string":  "string",
+	"text":    "string",
+	"int":     "int64",
+	"int64":   "int64",
+	"uint":    "uint64",
+	"float":   "double",
+	"decimal": "double",
+	"bool":    "bool",
+}
+
+const (
+	timestampType = "google.protobuf.Timestamp"
+	emptyType     = "google.protobuf.Empty"
+)
+
+// wellKnownProtos adalah file protobuf bawaan yang dapat di-import modul
+var wellKnownProtos = map[string]*descriptorpb.FileDescriptorProto{
+	timestampType: protodesc.ToFileDescriptorProto(timestamppb.File_google_protobuf_timestamp_proto),
+	emptyType:     protodesc.ToFileDescriptorProto(emptypb.File_google_protobuf_empty_proto),
+}
+
+// protoFile adalah model satu file .proto. Model yang sama dipakai untuk
+// menulis file .proto dan membentuk descriptor yang diberikan ke
+// protoc-gen-go, sehingga keduanya selalu sesuai.
+type protoFile struct {
+	Name      string // path relatif terhadap api/proto, mis. product.proto
+	Package   string // package protobuf, mis. shop.v1
+	GoPackage string // opsi go_package
+	Imports   []string
+	Messages  []*protoMessage
+	Service   *protoService
+}
+
+type protoMessage struct {
+	Name   string
+	Fields []*protoField
+}
+
+type protoField struct {
+	Name     string
+	Number   int32
+	Type     string // tipe scalar atau nama message, mis. google.protobuf.Timestamp
+	Optional bool   // proto3 optional untuk field scalar nullable
+	Repeated bool
+	MapKey   string // diisi untuk field map<MapKey, Type>
+}
+
+type protoService struct {
+	Name    string
+	Methods []protoMethod
+}
+
+type protoMethod struct {
+	Name   string
+	Input  string
+	Output string
+}
+
+// TypeName menulis tipe field pada file .proto, mis. map<string, string>
+func (f *protoField) TypeName() string {
+	if f.MapKey != "" {
+		return fmt.Sprintf("map<%s, %s>", f.MapKey, f.Type)
+	}
+	return f.Type
+}
+
+// GoOutput mengembalikan tipe Go hasil method pada kode protoc-gen-go-grpc,
+// mis. emptypb.Empty
+func (m protoMethod) GoOutput() string {
+	if m.Output == emptyType {
+		return "emptypb.Empty"
+	}
+	return m.Output
+}
+
+// protoPackage menurunkan package protobuf proyek dari path modul Go,
+// mis. github.com/acme/online-shop menjadi online_shop.v1
+func protoPackage(modulePath string) string {
+	name := strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, path.Base(modulePath))
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		name = "app" + name
+	}
+	return name + ".v1"
+}
+
+// paginationProtoFile membentuk pagination.proto yang berisi PageMeta
+func paginationProtoFile(modulePath string) *protoFile {
+	return &protoFile{
+		Name:      paginationProto,
+		Package:   protoPackage(modulePath),
+		GoPackage: modulePath + "/" + grpcPBDir + ";pb",
+		Messages: []*protoMessage{{
+			Name: "PageMeta",
+			Fields: []*protoField{
+				{Name: "page", Number: 1, Type: "int32"},
+				{Name: "page_size", Number: 2, Type: "int32"},
+				{Name: "total", Number: 3, Type: "int64"},
+				{Name: "total_pages", Number: 4, Type: "int32"},
+				{Name: "next_cursor", Number: 5, Type: "string"},
+			},
+		}},
+	}
+}
+
+// moduleProtoFile membentuk file .proto modul: message entity, request
+// setiap RPC, dan service CRUD
+func moduleProtoFile(d moduleData) (*protoFile, error) {
+	name, plural := d.Name, inflection.Plural(d.Name)
+	f := &protoFile{
+		Name:      toSnake(d.Name) + ".proto",
+		Package:   protoPackage(d.ModulePath),
+		GoPackage: d.ModulePath + "/" + grpcPBDir + ";pb",
+		Imports:   []string{paginationProto},
+	}
+
+	usesTime := d.Timestamps
+	entity := &protoMessage{Name: name, Fields: []*protoField{{Name: "id", Number: 1, Type: "uint64"}}}
+	for _, field := range d.ResponseFields() {
+		pf, err := protoFieldOf(field, int32(len(entity.Fields)+1))
+		if err != nil {
+			return nil, err
+		}
+		usesTime = usesTime || field.IsTime()
+		entity.Fields = append(entity.Fields, pf)
+	}
+	if d.Timestamps {
+		n := int32(len(entity.Fields))
+		entity.Fields = append(entity.Fields,
+			&protoField{Name: "created_at", Number: n + 1, Type: timestampType},
+			&protoField{Name: "updated_at", Number: n + 2, Type: timestampType})
+	}
+
+	requestFields := func(fields ...*protoField) ([]*protoField, error) {
+		for _, field := range d.RequestFields() {
+			pf, err := protoFieldOf(field, int32(len(fields)+1))
+			if err != nil {
+				return nil, err
+			}
+			usesTime = usesTime || field.IsTime()
+			fields = append(fields, pf)
+		}
+		return fields, nil
+	}
+	create, err := requestFields()
+	if err != nil {
+		return nil, err
+	}
+	update, err := requestFields(&protoField{Name: "id", Number: 1, Type: "uint64"})
+	if err != nil {
+		return nil, err
+	}
+
+	f.Messages = []*protoMessage{
+		entity,
+		{Name: "List" + plural + "Request", Fields: []*protoField{
+			{Name: "page", Number: 1, Type: "int32"},
+			{Name: "page_size", Number: 2, Type: "int32"},
+			{Name: "cursor", Number: 3, Type: "string", Optional: true},
+			{Name: "sort", Number: 4, Type: "string"},
+			{Name: "filter", Number: 5, Type: "string", MapKey: "string"},
+		}},
+		{Name: "List" + plural + "Response", Fields: []*protoField{
+			{Name: "data", Number: 1, Type: name, Repeated: true},
+			{Name: "meta", Number: 2, Type: "PageMeta"},
+		}},
+		{Name: "Get" + name + "Request", Fields: []*protoField{{Name: "id", Number: 1, Type: "uint64"}}},
+		{Name: "Create" + name + "Request", Fields: create},
+		{Name: "Update" + name + "Request", Fields: update},
+		{Name: "Delete" + name + "Request", Fields: []*protoField{{Name: "id", Number: 1, Type: "uint64"}}},
+	}
+	f.Service = &protoService{
+		Name: name + "Service",
+		Methods: []protoMethod{
+			{Name: "List" + plural, Input: "List" + plural + "Request", Output: "List" + plural + "Response"},
+			{Name: "Get" + name, Input: "Get" + name + "Request", Output: name},
+			{Name: "Create" + name, Input: "Create" + name + "Request", Output: name},
+			{Name: "Update" + name, Input: "Update" + name + "Request", Output: name},
+			{Name: "Delete" + name, Input: "Delete" + name + "Request", Output: emptyType},
+		},
+	}
+
+	if usesTime {
+		f.Imports = append(f.Imports, "google/protobuf/timestamp.proto")
+	}
+	f.Imports = append(f.Imports, "google/protobuf/empty.proto")
+	return f, nil
+}
+
+// protoFieldOf membentuk field protobuf dari field modul
+func protoFieldOf(field Field, number int32) (*protoField, error) {
+	pf := &protoField{Name: toSnake(field.Name), Number: number}
+	if field.IsTime() {
+		pf.Type = timestampType
+		return pf, nil
+	}
+	scalar, ok := protoScalars[field.Type]
+	if !ok {
+		return nil, fmt.Errorf("tipe %s pada field %s belum didukung delivery gRPC", field.Type, field.Name)
+	}
+	pf.Type, pf.Optional = scalar, field.Pointer()
+	return pf, nil
+}
+
+// descriptorTypes memetakan tipe scalar protobuf ke tipe descriptor
+var descriptorTypes = map[string]descriptorpb.FieldDescriptorProto_Type{
+	"string": descriptorpb.FieldDescriptorProto_TYPE_STRING,
+	"int32":  descriptorpb.FieldDescriptorProto_TYPE_INT32,
+	"int64":  descriptorpb.FieldDescriptorProto_TYPE_INT64,
+	"uint64": descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+	"double": descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+	"bool":   descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+}
+
+// descriptor membentuk FileDescriptorProto seperti yang dikirim protoc ke
+// plugin
+func (f *protoFile) descriptor() *descriptorpb.FileDescriptorProto {
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String(f.Name),
+		Package:    proto.String(f.Package),
+		Dependency: f.Imports,
+		Syntax:     proto.String("proto3"),
+		Options:    &descriptorpb.FileOptions{GoPackage: proto.String(f.GoPackage)},
+	}
+	for _, m := range f.Messages {
+		fd.MessageType = append(fd.MessageType, f.messageDescriptor(m))
+	}
+	if f.Service != nil {
+		sd := &descriptorpb.ServiceDescriptorProto{Name: proto.String(f.Service.Name)}
+		for _, m := range f.Service.Methods {
+			sd.Method = append(sd.Method, &descriptorpb.MethodDescriptorProto{
+				Name:       proto.String(m.Name),
+				InputType:  proto.String(f.typeName(m.Input)),
+				OutputType: proto.String(f.typeName(m.Output)),
+			})
+		}
+		fd.Service = append(fd.Service, sd)
+	}
+	return fd
+}
+
+func (f *protoFile) messageDescriptor(m *protoMessage) *descriptorpb.DescriptorProto {
+	md := &descriptorpb.DescriptorProto{Name: proto.String(m.Name)}
+	var oneofs []*descriptorpb.OneofDescriptorProto
+	for _, field := range m.Fields {
+		fd := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(field.Name),
+			Number:   proto.Int32(field.Number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			JsonName: proto.String(jsonCamelCase(field.Name)),
+		}
+		switch {
+		case field.MapKey != "":
+			// Field map adalah repeated message entry bertingkat
+			entry := toPascal(field.Name) + "Entry"
+			md.NestedType = append(md.NestedType, &descriptorpb.DescriptorProto{
+				Name: proto.String(entry),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					f.scalarDescriptor("key", 1, field.MapKey),
+					f.scalarDescriptor("value", 2, field.Type),
+				},
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			})
+			fd.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+			fd.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+			fd.TypeName = proto.String(f.typeName(m.Name + "." + entry))
+		case descriptorTypes[field.Type] != 0:
+			fd.Type = descriptorTypes[field.Type].Enum()
+		default:
+			fd.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+			fd.TypeName = proto.String(f.typeName(field.Type))
+		}
+		if field.Repeated {
+			fd.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		}
+		if field.Optional {
+			// proto3 optional memakai oneof sintetis _nama
+			fd.Proto3Optional = proto.Bool(true)
+			fd.OneofIndex = proto.Int32(int32(len(oneofs)))
+			oneofs = append(oneofs, &descriptorpb.OneofDescriptorProto{Name: proto.String("_" + field.Name)})
+		}
+		md.Field = append(md.Field, fd)
+	}
+	md.OneofDecl = oneofs
+	return md
+}
+
+func (f *protoFile) scalarDescriptor(name string, number int32, typ string) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     descriptorTypes[typ].Enum(),
+		JsonName: proto.String(name),
+	}
+}
+
+// typeName mengubah nama message menjadi nama lengkap descriptor. Nama
+// tanpa package berada pada package proyek.
+func (f *protoFile) typeName(name string) string {
+	if strings.HasPrefix(name, "google.") {
+		return "." + name
+	}
+	return "." + f.Package + "." + name
+}
+
+// jsonCamelCase menurunkan json_name field seperti protoc, mis. page_size
+// menjadi pageSize
+func jsonCamelCase(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// protoGoOutput adalah hasil generate kode Go dari file .proto
+type protoGoOutput struct {
+	files   map[string][]byte // nama file Go, mis. product.pb.go
+	goNames map[string]string // nama field Go per Message.field
+}
+
+// generateProtoGo menjalankan protoc-gen-go secara in-process atas
+// descriptor file, sehingga kode Go dapat dibuat tanpa protoc maupun
+// plugin yang terpasang. Hanya file pada generate yang ditulis; file lain
+// dipakai sebagai dependensi.
+func generateProtoGo(files []*protoFile, generate ...string) (*protoGoOutput, error) {
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: generate,
+		Parameter:      proto.String("paths=source_relative"),
+	}
+	for _, name := range []string{timestampType, emptyType} {
+		req.ProtoFile = append(req.ProtoFile, wellKnownProtos[name])
+	}
+	for _, f := range files {
+		req.ProtoFile = append(req.ProtoFile, f.descriptor())
+	}
+
+	plugin, err := protogen.Options{}.New(req)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca descriptor protobuf: %w", err)
+	}
+	plugin.SupportedFeatures = internal_gengo.SupportedFeatures
+
+	out := &protoGoOutput{files: make(map[string][]byte), goNames: make(map[string]string)}
+	for _, f := range plugin.Files {
+		if !f.Generate {
+			continue
+		}
+		internal_gengo.GenerateFile(plugin, f)
+		for _, m := range f.Messages {
+			for _, field := range m.Fields {
+				out.goNames[m.GoIdent.GoName+"."+string(field.Desc.Name())] = field.GoName
+			}
+		}
+	}
+
+	resp := plugin.Response()
+	if resp.Error != nil {
+		return nil, fmt.Errorf("gagal generate kode protobuf: %s", resp.GetError())
+	}
+	for _, f := range resp.File {
+		out.files[f.GetName()] = []byte(f.GetContent())
+	}
+	return out, nil
+}
+
+// grpcField adalah field modul beserta nama field protobuf dan nama field
+// Go hasil protoc-gen-go
+type grpcField struct {
+	Field
+	Proto  string // nama field protobuf, mis. category_id
+	GoName string // nama field Go pada package pb, mis. CategoryId
+}
+
+// grpcData adalah data template server gRPC dan kode protoc-gen-go-grpc
+type grpcData struct {
+	moduleData
+	File    *protoFile
+	Plural  string      // nama jamak entity, mis. Products
+	Entity  []grpcField // field message entity
+	Request []grpcField // field message create dan update
+}
+
+// Service mengembalikan service gRPC modul
+func (d grpcData) Service() *protoService {
+	return d.File.Service
+}
+
+// FullName mengembalikan nama lengkap service, mis. shop.v1.ProductService
+func (d grpcData) FullName() string {
+	return d.File.Package + "." + d.File.Service.Name
+}
+
+// castTypes adalah tipe Go field modul yang berbeda dari tipe Go field
+// protobuf-nya
+var castTypes = map[string]string{"int": "int64", "uint": "uint64"}
+
+// ToProto mengembalikan ekspresi nilai field protobuf dari entity v
+func (f grpcField) ToProto(v string) string {
+	value := v + "." + f.Name
+	goType := fieldTypes[f.Type].GoType
+	switch {
+	case f.IsTime() && f.Pointer():
+		return "toTimestamp(" + value + ")"
+	case f.IsTime():
+		return "timestamppb.New(" + value + ")"
+	case castTypes[goType] != "" && f.Pointer():
+		return "convertPtr[" + castTypes[goType] + "](" + value + ")"
+	case castTypes[goType] != "":
+		return castTypes[goType] + "(" + value + ")"
+	}
+	return value
+}
+
+// FromProto mengembalikan ekspresi nilai field entity dari message req
+func (f grpcField) FromProto(req string) string {
+	goType := fieldTypes[f.Type].GoType
+	switch {
+	case f.IsTime() && f.Pointer():
+		return "fromTimestamp(" + req + "." + f.GoName + ")"
+	case f.IsTime():
+		return req + ".Get" + f.GoName + "().AsTime()"
+	case castTypes[goType] != "" && f.Pointer():
+		return "convertPtr[" + goType + "](" + req + "." + f.GoName + ")"
+	case castTypes[goType] != "":
+		return goType + "(" + req + ".Get" + f.GoName + "())"
+	case f.Pointer():
+		return req + "." + f.GoName
+	}
+	return req + ".Get" + f.GoName + "()"
+}
+
+// generateGRPC membuat file .proto modul, kode Go protobuf dan gRPC pada
+// package pb, server gRPC di atas usecase modul, serta dependensi dan
+// target make proto pada proyek
+func (g *ModuleGenerator) generateGRPC(set *FileSet) error {
+	data := g.templateData()
+	file, err := moduleProtoFile(data)
+	if err != nil {
+		return err
+	}
+	pagination := paginationProtoFile(g.modulePath)
+
+	renderer := NewRenderer(g.rootDir, g.modulePath)
+	render := func(dir, name, tmpl string, data interface{}) error {
+		target := filepath.Join(g.rootDir, filepath.FromSlash(dir), name)
+		content, err := renderer.RenderFile(tmpl, target, data)
+		if err != nil {
+			return err
+		}
+		set.Add(target, content)
+		return nil
+	}
+
+	// pagination.proto dibuat sekali dan dipakai bersama seluruh modul
+	generate := []string{file.Name}
+	paginationPath := filepath.Join(g.rootDir, filepath.FromSlash(protoDir), paginationProto)
+	if _, exists, err := currentContent(set, paginationPath); err != nil {
+		return err
+	} else if !exists {
+		if err := render(protoDir, paginationProto, "grpc/proto.tmpl", pagination); err != nil {
+			return err
+		}
+		generate = append(generate, paginationProto)
+	}
+	if err := render(protoDir, file.Name, "grpc/proto.tmpl", file); err != nil {
+		return err
+	}
+
+	out, err := generateProtoGo([]*protoFile{pagination, file}, generate...)
+	if err != nil {
+		return err
+	}
+	for name, content := range out.files {
+		set.Add(filepath.Join(g.rootDir, filepath.FromSlash(grpcPBDir), name), content)
+	}
+
+	server := grpcData{
+		moduleData: data,
+		File:       file,
+		Plural:     inflection.Plural(data.Name),
+	}
+	for _, f := range data.ResponseFields() {
+		name := toSnake(f.Name)
+		server.Entity = append(server.Entity, grpcField{Field: f, Proto: name, GoName: out.goNames[data.Name+"."+name]})
+	}
+	for _, f := range data.RequestFields() {
+		name := toSnake(f.Name)
+		server.Request = append(server.Request, grpcField{Field: f, Proto: name, GoName: out.goNames["Create"+data.Name+"Request."+name]})
+	}
+
+	base := strings.TrimSuffix(file.Name, ".proto")
+	if err := render(grpcPBDir, base+"_grpc.pb.go", "grpc/service_grpc.pb.go.tmpl", server); err != nil {
+		return err
+	}
+	if err := render(grpcDir, g.moduleName+"_server.go", "grpc/server.go.tmpl", server); err != nil {
+		return err
+	}
+
+	for _, f := range grpcSharedFiles {
+		target := filepath.Join(g.rootDir, filepath.FromSlash(f.path))
+		if _, exists, err := currentContent(set, target); err != nil {
+			return err
+		} else if exists {
+			continue
+		}
+		dir, name := path.Split(f.path)
+		if err := render(dir, name, f.tmpl, data); err != nil {
+			return err
+		}
+	}
+
+	if err := g.addRequires(set, grpcRequires); err != nil {
+		return err
+	}
+	return g.addProtoTarget(set, renderer)
+}
+
+// addProtoTarget menambahkan target make proto-tools dan proto ke Makefile
+// proyek jika belum ada
+func (g *ModuleGenerator) addProtoTarget(set *FileSet, renderer *Renderer) error {
+	makefile := filepath.Join(g.rootDir, "Makefile")
+	src, ok, err := currentContent(set, makefile)
+	if err != nil || !ok {
+		return err
+	}
+	if strings.Contains("\n"+string(src), "\nproto:") {
+		return nil
+	}
+
+	block, err := renderer.Render("grpc/Makefile.tmpl", g.templateData())
+	if err != nil {
+		return err
+	}
+	content := strings.TrimRight(string(src), "\n") + "\n\n" + string(block)
+	set.Update(makefile, []byte(content))
+	return nil
+}
@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// httpProfile mengumpulkan semua hal yang bergantung pada framework HTTP
+// proyek. main.go, handler modul, penulisan error, Swagger UI, dan wiring
+// modul baru pada main.go diturunkan dari profile yang sama.
+type httpProfile struct {
+	Name      string
+	Requires  []module // dependensi framework pada go.mod
+	GoVersion string   // versi Go minimum pada go.mod
+	Handler   string   // template handler modul
+	// RouterPkg dan RouterFunc adalah pemanggilan pembuat router pada
+	// main.go, mis. mux.NewRouter, yang dicari saat mendaftarkan modul
+	RouterPkg  string
+	RouterFunc string
+	RouterVar  string // variabel router pada main.go bawaan
+	// RouterType adalah tipe parameter RegisterRoutes, mis. *mux.Router
+	RouterType string
+	// ColonParams menandakan path parameter ditulis :id, bukan {id}
+	ColonParams bool
+	// paramExpr adalah ekspresi Go untuk membaca path parameter; %s
+	// diganti nama parameter
+	paramExpr string
+}
+
+// DefaultHTTP adalah framework HTTP proyek yang dibuat tanpa --http dan
+// proyek lama yang belum memiliki konfigurasi capy
+const DefaultHTTP = "mux"
+
+// httpProfiles berisi profile setiap framework HTTP yang didukung
+var httpProfiles = map[string]*httpProfile{
+	"mux": {
+		Name:       "mux",
+		Requires:   []module{{"github.com/gorilla/mux", "v1.8.1"}},
+		GoVersion:  "1.21",
+		Handler:    "module/handler.go.tmpl",
+		RouterPkg:  "mux",
+		RouterFunc: "NewRouter",
+		RouterVar:  "r",
+		RouterType: "*mux.Router",
+		paramExpr:  `mux.Vars(r)["%s"]`,
+	},
+	"chi": {
+		Name:       "chi",
+		Requires:   []module{{"github.com/go-chi/chi/v5", "v5.0.12"}},
+		GoVersion:  "1.21",
+		Handler:    "module/handler.go.tmpl",
+		RouterPkg:  "chi",
+		RouterFunc: "NewRouter",
+		RouterVar:  "r",
+		RouterType: "chi.Router",
+		paramExpr:  `chi.URLParam(r, "%s")`,
+	},
+	"stdlib": {
+		Name:       "stdlib",
+		GoVersion:  "1.22", // pola method dan wildcard pada http.ServeMux
+		Handler:    "module/handler.go.tmpl",
+		RouterPkg:  "http",
+		RouterFunc: "NewServeMux",
+		RouterVar:  "mux",
+		RouterType: "*http.ServeMux",
+		paramExpr:  `r.PathValue("%s")`,
+	},
+	"gin": {
+		Name:        "gin",
+		Requires:    []module{{"github.com/gin-gonic/gin", "v1.9.1"}},
+		GoVersion:   "1.21",
+		Handler:     "module/handler_gin.go.tmpl",
+		RouterPkg:   "gin",
+		RouterFunc:  "New",
+		RouterVar:   "r",
+		RouterType:  "gin.IRouter",
+		ColonParams: true,
+		paramExpr:   `c.Param("%s")`,
+	},
+	"echo": {
+		Name:        "echo",
+		Requires:    []module{{"github.com/labstack/echo/v4", "v4.11.4"}},
+		GoVersion:   "1.21",
+		Handler:     "module/handler_echo.go.tmpl",
+		RouterPkg:   "echo",
+		RouterFunc:  "New",
+		RouterVar:   "e",
+		RouterType:  "*echo.Echo",
+		ColonParams: true,
+		paramExpr:   `c.Param("%s")`,
+	},
+	"fiber": {
+		Name:        "fiber",
+		Requires:    []module{{"github.com/gofiber/fiber/v2", "v2.52.5"}},
+		GoVersion:   "1.21",
+		Handler:     "module/handler_fiber.go.tmpl",
+		RouterPkg:   "fiber",
+		RouterFunc:  "New",
+		RouterVar:   "app",
+		RouterType:  "fiber.Router",
+		ColonParams: true,
+		paramExpr:   `c.Params("%s")`,
+	},
+}
+
+// lookupHTTPProfile mengembalikan profile framework HTTP name; kosong
+// berarti DefaultHTTP
+func lookupHTTPProfile(name string) (*httpProfile, error) {
+	if name == "" {
+		name = DefaultHTTP
+	}
+	profile, ok := httpProfiles[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("framework HTTP tidak didukung: %s (pilihan: %s)", name, strings.Join(HTTPFrameworks(), ", "))
+	}
+	return profile, nil
+}
+
+// HTTPFrameworks mengembalikan nama framework HTTP yang didukung
+func HTTPFrameworks() []string {
+	names := make([]string, 0, len(httpProfiles))
+	for name := range httpProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// Route menulis path operasi, mis. /products/{id}, dengan sintaks path
+// parameter framework
+func (p *httpProfile) Route(path string) string {
+	if !p.ColonParams {
+		return path
+	}
+	return pathParamPattern.ReplaceAllString(path, ":$1")
+}
+
+// Method menulis nama method pendaftaran route framework, mis. GET pada
+// gin dan echo atau Get pada chi dan fiber
+func (p *httpProfile) Method(method string) string {
+	switch p.Name {
+	case "gin", "echo":
+		return strings.ToUpper(method)
+	}
+	return toPascal(strings.ToLower(method))
+}
+
+// PathValue mengembalikan ekspresi Go yang membaca path parameter name
+// di dalam handler
+func (p *httpProfile) PathValue(name string) string {
+	return fmt.Sprintf(p.paramExpr, name)
+}
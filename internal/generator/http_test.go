@@ -0,0 +1,172 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHTTPProfileRoute(t *testing.T) {
+	tests := []struct {
+		framework string
+		route     string
+		method    string
+		pathValue string
+	}{
+		{framework: "mux", route: "/orders/{id}/items/{itemId}", method: "Get", pathValue: `mux.Vars(r)["id"]`},
+		{framework: "chi", route: "/orders/{id}/items/{itemId}", method: "Get", pathValue: `chi.URLParam(r, "id")`},
+		{framework: "stdlib", route: "/orders/{id}/items/{itemId}", method: "Get", pathValue: `r.PathValue("id")`},
+		{framework: "gin", route: "/orders/:id/items/:itemId", method: "GET", pathValue: `c.Param("id")`},
+		{framework: "echo", route: "/orders/:id/items/:itemId", method: "GET", pathValue: `c.Param("id")`},
+		{framework: "fiber", route: "/orders/:id/items/:itemId", method: "Get", pathValue: `c.Params("id")`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.framework, func(t *testing.T) {
+			p, err := lookupHTTPProfile(tt.framework)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := p.Route("/orders/{id}/items/{itemId}"); got != tt.route {
+				t.Errorf("Route = %q, want %q", got, tt.route)
+			}
+			if got := p.Method("GET"); got != tt.method {
+				t.Errorf("Method = %q, want %q", got, tt.method)
+			}
+			if got := p.PathValue("id"); got != tt.pathValue {
+				t.Errorf("PathValue = %q, want %q", got, tt.pathValue)
+			}
+		})
+	}
+
+	if _, err := lookupHTTPProfile("martini"); err == nil || !strings.Contains(err.Error(), "framework HTTP tidak didukung: martini") {
+		t.Errorf("error = %v, want framework tidak didukung", err)
+	}
+}
+
+// Framework dipilih sekali pada capy new lalu dipakai modul berikutnya dari
+// .capy/config.yaml
+func TestHTTPFrameworkModule(t *testing.T) {
+	tests := []struct {
+		framework string
+		require   string // dependensi framework pada go.mod, kosong untuk stdlib
+		goVersion string
+		router    string // pembuatan router pada main.go
+		register  string // pendaftaran handler modul pada main.go
+		handler   []string
+	}{
+		{
+			framework: "mux",
+			require:   "github.com/gorilla/mux",
+			goVersion: "go 1.21",
+			router:    "r := mux.NewRouter()",
+			register:  "productHandler.RegisterRoutes(r)",
+			handler: []string{
+				"func (h *ProductHandler) RegisterRoutes(r *mux.Router) {",
+				`r.HandleFunc("/products/{id}", h.GetByID).Methods("GET")`,
+				`strconv.ParseUint(mux.Vars(r)["id"], 10, 32)`,
+				"func (h *ProductHandler) GetAll(w http.ResponseWriter, r *http.Request) {",
+			},
+		},
+		{
+			framework: "chi",
+			require:   "github.com/go-chi/chi/v5",
+			goVersion: "go 1.21",
+			router:    "r := chi.NewRouter()",
+			register:  "productHandler.RegisterRoutes(r)",
+			handler: []string{
+				"func (h *ProductHandler) RegisterRoutes(r chi.Router) {",
+				`r.Get("/products/{id}", h.GetByID)`,
+				`strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)`,
+			},
+		},
+		{
+			framework: "stdlib",
+			goVersion: "go 1.22",
+			router:    "mux := http.NewServeMux()",
+			register:  "productHandler.RegisterRoutes(mux)",
+			handler: []string{
+				"func (h *ProductHandler) RegisterRoutes(r *http.ServeMux) {",
+				`r.HandleFunc("GET /products/{id}", h.GetByID)`,
+				`strconv.ParseUint(r.PathValue("id"), 10, 32)`,
+			},
+		},
+		{
+			framework: "gin",
+			require:   "github.com/gin-gonic/gin",
+			goVersion: "go 1.21",
+			router:    "r := gin.New()",
+			register:  "productHandler.RegisterRoutes(r)",
+			handler: []string{
+				"func (h *ProductHandler) RegisterRoutes(r gin.IRouter) {",
+				`r.GET("/products/:id", h.GetByID)`,
+				`strconv.ParseUint(c.Param("id"), 10, 32)`,
+				"func (h *ProductHandler) GetAll(c *gin.Context) {",
+			},
+		},
+		{
+			framework: "echo",
+			require:   "github.com/labstack/echo/v4",
+			goVersion: "go 1.21",
+			router:    "e := echo.New()",
+			register:  "productHandler.RegisterRoutes(e)",
+			handler: []string{
+				"func (h *ProductHandler) RegisterRoutes(r *echo.Echo) {",
+				`r.GET("/products/:id", h.GetByID)`,
+				`strconv.ParseUint(c.Param("id"), 10, 32)`,
+				"func (h *ProductHandler) GetAll(c echo.Context) error {",
+			},
+		},
+		{
+			framework: "fiber",
+			require:   "github.com/gofiber/fiber/v2",
+			goVersion: "go 1.21",
+			router:    "app := fiber.New()",
+			register:  "productHandler.RegisterRoutes(app)",
+			handler: []string{
+				"func (h *ProductHandler) RegisterRoutes(r fiber.Router) {",
+				`r.Get("/products/:id", h.GetByID)`,
+				`strconv.ParseUint(c.Params("id"), 10, 32)`,
+				"func (h *ProductHandler) GetAll(c *fiber.Ctx) error {",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.framework, func(t *testing.T) {
+			root := newProject(t, tt.framework).Root
+			project, err := DetectProject(root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if project.HTTP != tt.framework {
+				t.Fatalf("HTTP dari konfigurasi = %q, want %q", project.HTTP, tt.framework)
+			}
+			generateDelivery(t, project, "product", nil, "name:string")
+
+			handler := readFile(t, filepath.Join(root, "internal", "delivery", "http", "product_handler.go"))
+			for _, want := range tt.handler {
+				if !strings.Contains(handler, want) {
+					t.Errorf("handler tidak memuat %q:\n%s", want, handler)
+				}
+			}
+
+			main := readFile(t, filepath.Join(root, "cmd", "main.go"))
+			for _, want := range []string{tt.router, tt.register} {
+				if strings.Count(main, want) != 1 {
+					t.Errorf("main.go memuat %q %d kali, want 1:\n%s", want, strings.Count(main, want), main)
+				}
+			}
+
+			gomod := readFile(t, filepath.Join(root, "go.mod"))
+			if !strings.Contains(gomod, "\n"+tt.goVersion+"\n") {
+				t.Errorf("go.mod tidak memuat %q:\n%s", tt.goVersion, gomod)
+			}
+			for name, p := range httpProfiles {
+				for _, req := range p.Requires {
+					if got := strings.Contains(gomod, req.Path+" "); got != (name == tt.framework) {
+						t.Errorf("go.mod memuat %s = %v:\n%s", req.Path, got, gomod)
+					}
+				}
+			}
+		})
+	}
+}
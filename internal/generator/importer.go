@@ -0,0 +1,196 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/inflection"
+)
+
+// ModuleSchema adalah modul yang dibentuk dari skema yang sudah ada, mis.
+// hasil membaca database, file DDL, atau spesifikasi OpenAPI
+type ModuleSchema struct {
+	Name       string // nama modul, mis. order
+	Table      TableSpec
+	Fields     []Field
+	Operations []Operation // nil berarti route CRUD bawaan
+	DTOs       []DTO
+	Warnings   []string // kolom, index, atau operasi yang tidak dapat dipetakan
+}
+
+// unsupportedTableError menandakan tabel yang tidak dapat dipetakan ke modul,
+// mis. karena composite primary key
+type unsupportedTableError struct {
+	table  string
+	reason string
+}
+
+func (e *unsupportedTableError) Error() string {
+	return "tabel " + e.table + ": " + e.reason
+}
+
+// moduleFromTable memetakan tabel ke modul capy. Tabel harus memiliki satu
+// kolom primary key integer; kolom dengan tipe yang tidak dikenal dan
+// composite index dilewati dengan peringatan.
+func moduleFromTable(t table) (ModuleSchema, error) {
+	var pk *column
+	for i, c := range t.Columns {
+		if !c.PrimaryKey {
+			continue
+		}
+		if pk != nil {
+			return ModuleSchema{}, &unsupportedTableError{t.Name, "composite primary key tidak didukung"}
+		}
+		pk = &t.Columns[i]
+	}
+	if pk == nil {
+		return ModuleSchema{}, &unsupportedTableError{t.Name, "primary key tidak ditemukan"}
+	}
+	switch pk.Type {
+	case "int", "int64", "uint":
+	default:
+		return ModuleSchema{}, &unsupportedTableError{t.Name, "primary key " + pk.Name + " harus bertipe integer"}
+	}
+
+	schema := ModuleSchema{Name: inflection.Singular(t.Name)}
+	schema.Table.Exists = true
+	if tableName(schema.Name) != t.Name {
+		schema.Table.Name = t.Name
+	}
+	if pk.Name != "id" {
+		schema.Table.PrimaryKey = pk.Name
+	}
+	created, updated := t.column("created_at"), t.column("updated_at")
+	timestamps := created != nil && created.Type == "time" && updated != nil && updated.Type == "time"
+	schema.Table.NoTimestamps = !timestamps
+
+	// index satu kolom per nama kolom; unique index diutamakan
+	indexes := make(map[string]index)
+	for _, idx := range t.Indexes {
+		if len(idx.Columns) != 1 {
+			schema.Warnings = append(schema.Warnings, fmt.Sprintf("composite index %s pada tabel %s dilewati", idx.Name, t.Name))
+			continue
+		}
+		if existing, ok := indexes[idx.Columns[0]]; !ok || idx.Unique && !existing.Unique {
+			indexes[idx.Columns[0]] = idx
+		}
+	}
+
+	for _, c := range t.Columns {
+		// Tanpa pasangan created_at dan updated_at, entity tidak memakai
+		// timestamps bawaan sehingga kolom timestamp yang ada menjadi field
+		// biasa; GORM tetap mengisi field CreatedAt dan UpdatedAt otomatis
+		timestamp := c.Name == "created_at" || c.Name == "updated_at"
+		if c.PrimaryKey || timestamps && timestamp {
+			continue
+		}
+		if isBaseColumn(c.Name) && !timestamp {
+			schema.Warnings = append(schema.Warnings, fmt.Sprintf("kolom %s.%s bentrok dengan field bawaan entity dan dilewati", t.Name, c.Name))
+			continue
+		}
+		ft, ok := fieldTypes[c.Type]
+		if !ok {
+			schema.Warnings = append(schema.Warnings, fmt.Sprintf("kolom %s.%s bertipe %s tidak didukung dan dilewati", t.Name, c.Name, c.sqlType))
+			continue
+		}
+
+		f := Field{
+			Name:       toPascal(c.Name),
+			Column:     c.Name,
+			JSONName:   c.Name,
+			Type:       c.Type,
+			Size:       c.Size,
+			GoType:     ft.GoType,
+			Nullable:   c.Nullable,
+			ForeignKey: c.References != "",
+			RefTable:   c.References,
+			OnDelete:   c.OnDelete,
+			OnUpdate:   c.OnUpdate,
+			Default:    c.Default,
+			HasDefault: c.HasDefault,
+			ReadOnly:   timestamp && c.Type == "time",
+		}
+		// Hanya index yang benar-benar ada pada tabel yang dicatat; foreign
+		// key tanpa index tidak diberi index bawaan
+		if idx, ok := indexes[c.Name]; ok {
+			f.Unique = idx.Unique
+			f.Index = !idx.Unique
+			if idx.Name != indexName(t.Name, c.Name) {
+				f.IndexName = idx.Name
+			}
+		} else {
+			f.Unique = c.Unique
+			f.NoIndex = c.Unique || f.ForeignKey
+		}
+		if f.Pointer() {
+			f.GoType = "*" + f.GoType
+		}
+		schema.Fields = append(schema.Fields, f)
+	}
+	return schema, nil
+}
+
+// fieldTypeFromSQL memetakan tipe kolom SQL (PostgreSQL, MySQL, atau
+// SQLite) ke tipe field spec, atau string kosong jika tidak didukung
+func fieldTypeFromSQL(sqlType string) string {
+	t := strings.ToLower(strings.TrimSpace(sqlType))
+	unsigned := strings.Contains(t, "unsigned")
+	base := t
+	if i := strings.IndexAny(t, "( "); i >= 0 {
+		base = t[:i]
+	}
+
+	switch base {
+	case "bool", "boolean":
+		return "bool"
+	case "tinyint":
+		if strings.HasPrefix(t, "tinyint(1)") {
+			return "bool"
+		}
+		fallthrough
+	case "int", "integer", "int2", "int4", "smallint", "mediumint", "serial", "serial4", "smallserial":
+		if unsigned {
+			return "uint"
+		}
+		return "int"
+	case "bigint", "int8", "bigserial", "serial8":
+		if unsigned {
+			return "uint"
+		}
+		return "int64"
+	case "decimal", "numeric", "money":
+		return "decimal"
+	case "real", "float", "float4", "float8", "double":
+		return "float"
+	case "date":
+		return "date"
+	case "timestamp", "timestamptz", "datetime":
+		return "time"
+	case "text", "tinytext", "mediumtext", "longtext", "clob":
+		return "text"
+	case "char", "varchar", "character", "nchar", "nvarchar", "varchar2", "uuid", "citext", "enum":
+		return "string"
+	}
+	return ""
+}
+
+// normalizeDefault mengubah nilai default kolom dari database atau DDL
+// menjadi nilai modifier default, mis. 'draft'::character varying menjadi
+// draft. Default berupa sequence atau NULL diabaikan.
+func normalizeDefault(value string) (string, bool) {
+	v := strings.TrimSpace(value)
+	lower := strings.ToLower(v)
+	if v == "" || lower == "null" || strings.HasPrefix(lower, "nextval(") {
+		return "", false
+	}
+	if strings.HasPrefix(v, "(") && strings.HasSuffix(v, ")") {
+		v = strings.TrimSpace(v[1 : len(v)-1])
+	}
+	if i := strings.Index(v, "::"); i > 0 {
+		v = v[:i]
+	}
+	if len(v) >= 2 && v[0] == '\'' && v[len(v)-1] == '\'' {
+		v = strings.ReplaceAll(v[1:len(v)-1], "''", "'")
+	}
+	return v, true
+}
@@ -0,0 +1,206 @@
+package generator
+
+import (
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sqliteDatabase membuat file database SQLite dari statement DDL
+func sqliteDatabase(t *testing.T, stmts ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "legacy.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for _, stmt := range stmts {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	return path
+}
+
+const (
+	customersDDL = `CREATE TABLE customers (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		email VARCHAR(191) NOT NULL UNIQUE,
+		name VARCHAR(255) NOT NULL,
+		balance DECIMAL(10,2) NOT NULL DEFAULT 0,
+		status TEXT NOT NULL DEFAULT 'active',
+		created_at DATETIME,
+		updated_at DATETIME
+	)`
+	orderItemsDDL = `CREATE TABLE order_items (
+		id INTEGER PRIMARY KEY,
+		customer_id INTEGER NOT NULL REFERENCES customers (id) ON DELETE CASCADE,
+		sku TEXT NOT NULL,
+		qty INTEGER
+	)`
+	orderItemsSKU = `CREATE UNIQUE INDEX ux_order_items_sku ON order_items (sku)`
+	tagsDDL       = `CREATE TABLE post_tags (post_id INTEGER, tag_id INTEGER, PRIMARY KEY (post_id, tag_id))`
+)
+
+func TestReadDatabaseSchemaSQLite(t *testing.T) {
+	path := sqliteDatabase(t, customersDDL, orderItemsDDL, orderItemsSKU)
+
+	schemas, warnings, err := ReadDatabaseSchema("sqlite://"+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("warnings = %q, want kosong", warnings)
+	}
+	if len(schemas) != 2 || schemas[0].Name != "customer" || schemas[1].Name != "order_item" {
+		t.Fatalf("modul = %+v, want customer dan order_item", schemas)
+	}
+
+	tags := func(s ModuleSchema) map[string]string {
+		m := make(map[string]string)
+		for _, f := range s.Fields {
+			m[f.Column] = f.GormTag()
+		}
+		return m
+	}
+	customers := schemas[0]
+	if customers.Table.NoTimestamps || !customers.Table.Exists {
+		t.Errorf("table = %+v, want timestamps dan Exists", customers.Table)
+	}
+	wantCustomers := map[string]string{
+		"email":   "column:email;size:191;not null;unique",
+		"name":    "column:name;not null",
+		"balance": "column:balance;type:decimal(10,2);not null;default:0",
+		"status":  "column:status;type:text;not null;default:active",
+	}
+	if got := tags(customers); !reflect.DeepEqual(got, wantCustomers) {
+		t.Errorf("tag customers = %v, want %v", got, wantCustomers)
+	}
+	wantItems := map[string]string{
+		"customer_id": "column:customer_id;not null",
+		"sku":         "column:sku;type:text;not null;uniqueIndex:ux_order_items_sku",
+		"qty":         "column:qty",
+	}
+	if got := tags(schemas[1]); !reflect.DeepEqual(got, wantItems) {
+		t.Errorf("tag order_items = %v, want %v", got, wantItems)
+	}
+
+	// Skema yang dicatat snapshot hanya berisi index yang ada di database
+	items := tableFromFields(schemas[1].Name, schemas[1].Table, schemas[1].Fields)
+	wantIndexes := []index{{Name: "ux_order_items_sku", Columns: []string{"sku"}, Unique: true}}
+	if !reflect.DeepEqual(items.Indexes, wantIndexes) {
+		t.Errorf("index order_items = %+v, want %+v", items.Indexes, wantIndexes)
+	}
+	if c := items.column("customer_id"); c == nil || c.References != "customers" || c.OnDelete != "CASCADE" || c.OnUpdate != "" {
+		t.Errorf("customer_id = %+v, want referensi customers dengan ON DELETE CASCADE", c)
+	}
+	cust := tableFromFields(customers.Name, customers.Table, customers.Fields)
+	if len(cust.Indexes) != 0 {
+		t.Errorf("index customers = %+v, want kosong", cust.Indexes)
+	}
+	if c := cust.column("email"); c == nil || !c.Unique || c.Size != "191" {
+		t.Errorf("email = %+v, want unique dengan size 191", c)
+	}
+	if c := cust.column("balance"); c == nil || c.Size != "10,2" {
+		t.Errorf("balance = %+v, want size 10,2", c)
+	}
+}
+
+func TestReadDatabaseSchemaUnsupportedTable(t *testing.T) {
+	path := sqliteDatabase(t, customersDDL, tagsDDL)
+
+	schemas, warnings, err := ReadDatabaseSchema(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schemas) != 1 || schemas[0].Name != "customer" {
+		t.Errorf("modul = %+v, want customer saja", schemas)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "tabel post_tags: composite primary key tidak didukung") {
+		t.Errorf("warnings = %q, want peringatan post_tags", warnings)
+	}
+
+	// Tabel yang diminta secara eksplisit tetap menghasilkan error
+	if _, _, err := ReadDatabaseSchema(path, []string{"post_tags"}); err == nil || !strings.Contains(err.Error(), "composite primary key") {
+		t.Errorf("error = %v, want composite primary key", err)
+	}
+}
+
+func TestReadDatabaseSchemaErrors(t *testing.T) {
+	path := sqliteDatabase(t, customersDDL)
+	tests := []struct {
+		name    string
+		dsn     string
+		tables  []string
+		wantErr string
+	}{
+		{name: "dsn tidak dikenal", dsn: "oracle://localhost/db", wantErr: "DSN tidak dikenali"},
+		{name: "file tidak ada", dsn: "sqlite://" + filepath.Join(t.TempDir(), "missing.db"), wantErr: "tidak ditemukan"},
+		{name: "tabel tidak ada", dsn: path, tables: []string{"orders"}, wantErr: "tabel orders tidak ditemukan"},
+		{name: "hanya schema_migrations", dsn: sqliteDatabase(t, "CREATE TABLE schema_migrations (version INTEGER)"), wantErr: "database tidak memiliki tabel"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ReadDatabaseSchema(tt.dsn, tt.tables)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Tabel dengan salah satu kolom timestamp saja tidak memakai timestamps
+// bawaan, tetapi kolom tersebut tetap diimpor sebagai field readonly
+func TestReadDatabaseSchemaSingleTimestamp(t *testing.T) {
+	tests := []struct {
+		name   string
+		ddl    string
+		column string
+		want   Field
+	}{
+		{
+			name:   "created_at saja",
+			ddl:    `CREATE TABLE events (id INTEGER PRIMARY KEY, title TEXT NOT NULL, created_at DATETIME NOT NULL)`,
+			column: "created_at",
+			want:   Field{Name: "CreatedAt", Column: "created_at", JSONName: "created_at", Type: "time", GoType: "time.Time", ReadOnly: true},
+		},
+		{
+			name:   "updated_at saja",
+			ddl:    `CREATE TABLE events (id INTEGER PRIMARY KEY, title TEXT NOT NULL, updated_at DATETIME)`,
+			column: "updated_at",
+			want:   Field{Name: "UpdatedAt", Column: "updated_at", JSONName: "updated_at", Type: "time", GoType: "*time.Time", Nullable: true, ReadOnly: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemas, warnings, err := ReadDatabaseSchema(sqliteDatabase(t, tt.ddl), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(warnings) != 0 {
+				t.Errorf("warnings = %q, want kosong", warnings)
+			}
+			if len(schemas) != 1 || !schemas[0].Table.NoTimestamps {
+				t.Fatalf("modul = %+v, want satu modul tanpa timestamps", schemas)
+			}
+			var got *Field
+			for i, f := range schemas[0].Fields {
+				if f.Column == tt.column {
+					got = &schemas[0].Fields[i]
+				}
+			}
+			if got == nil || !reflect.DeepEqual(*got, tt.want) {
+				t.Fatalf("field %s = %+v, want %+v", tt.column, got, tt.want)
+			}
+
+			// Kolom timestamp tercatat pada skema migration modul
+			events := tableFromFields(schemas[0].Name, schemas[0].Table, schemas[0].Fields)
+			if c := events.column(tt.column); c == nil || c.Type != "time" || c.Nullable != tt.want.Nullable {
+				t.Errorf("kolom %s = %+v, want time dengan nullable %v", tt.column, c, tt.want.Nullable)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"strings"
+)
+
+// listField adalah satu field pada whitelist sort dan filter list endpoint
+type listField struct {
+	Name   string   // parameter query, sama dengan properti JSON
+	Column string   // kolom database
+	Type   string   // tipe nilai pada package query, mis. Int
+	Ops    []string // operator filter, mis. eq dan like
+}
+
+// OpsExpr mengembalikan operator filter sebagai konstanta package query
+func (f listField) OpsExpr() string {
+	consts := make([]string, 0, len(f.Ops))
+	for _, op := range f.Ops {
+		consts = append(consts, "query.Op"+toPascal(op))
+	}
+	return strings.Join(consts, ", ")
+}
+
+// ListFields mengembalikan whitelist sort dan filter GET list modul:
+// primary key, field yang tampil pada respons, lalu timestamp. Operator
+// filter diturunkan dari tipe field.
+func (d moduleData) ListFields() []listField {
+	pk := "id"
+	if d.PrimaryKey != "" {
+		pk = d.PrimaryKey
+	}
+	fields := []listField{{Name: "id", Column: pk, Type: "Int", Ops: []string{"eq", "gt", "lt", "in"}}}
+	for _, f := range d.ResponseFields() {
+		lf := listField{Name: f.JSONName, Column: f.Column}
+		switch {
+		case f.isString():
+			lf.Type, lf.Ops = "String", []string{"eq", "like", "in"}
+		case f.Type == "bool":
+			lf.Type, lf.Ops = "Bool", []string{"eq"}
+		case f.IsTime():
+			lf.Type, lf.Ops = "Time", []string{"eq", "gt", "lt"}
+		case f.Type == "float" || f.Type == "decimal":
+			lf.Type, lf.Ops = "Float", []string{"eq", "gt", "lt"}
+		default:
+			lf.Type, lf.Ops = "Int", []string{"eq", "gt", "lt", "in"}
+		}
+		fields = append(fields, lf)
+	}
+	if d.Timestamps {
+		for _, name := range []string{"created_at", "updated_at"} {
+			fields = append(fields, listField{Name: name, Column: name, Type: "Time", Ops: []string{"eq", "gt", "lt"}})
+		}
+	}
+	return fields
+}
@@ -0,0 +1,199 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrNoSchemaChanges dikembalikan jika entity sama dengan snapshot skema
+var ErrNoSchemaChanges = errors.New("tidak ada perubahan skema")
+
+// MigrationGenerator membuat migration dari perbedaan struct entity dengan
+// snapshot skema yang disimpan capy setelah migration terakhir
+type MigrationGenerator struct {
+	name         string
+	rootDir      string
+	modulePath   string
+	databaseType string
+	renames      map[string]map[string]string
+	writer       *Writer
+	now          func() time.Time
+}
+
+// NewMigrationGenerator membuat instance baru MigrationGenerator untuk
+// migration bernama name, mis. add_stock_to_products
+func NewMigrationGenerator(name string) *MigrationGenerator {
+	return &MigrationGenerator{
+		name:   toSnake(name),
+		writer: NewWriter(),
+		now:    time.Now,
+	}
+}
+
+// SetProject mengatur direktori root, import path, dan database dari hasil DetectProject
+func (g *MigrationGenerator) SetProject(project *Project) {
+	g.rootDir = project.Root
+	g.modulePath = project.ModulePath
+	g.databaseType = project.Database
+}
+
+// SetDatabaseType mengatur database yang menentukan dialect SQL migration
+func (g *MigrationGenerator) SetDatabaseType(dbType string) {
+	g.databaseType = dbType
+}
+
+// SetRenames mengatur kolom yang di-rename hasil ParseRenames. Tanpa rename,
+// kolom yang namanya berubah dianggap dihapus lalu ditambahkan.
+func (g *MigrationGenerator) SetRenames(renames map[string]map[string]string) {
+	g.renames = renames
+}
+
+// SetWriter mengatur Writer yang dipakai Generate, mis. untuk mode dry-run
+func (g *MigrationGenerator) SetWriter(w *Writer) {
+	g.writer = w
+}
+
+// ParseRenames mengubah daftar rename dengan format tabel.kolom_lama=kolom_baru
+func ParseRenames(specs []string) (map[string]map[string]string, error) {
+	renames := make(map[string]map[string]string)
+	for _, spec := range specs {
+		ref, name, ok := strings.Cut(spec, "=")
+		tableName, old, hasTable := strings.Cut(ref, ".")
+		if !ok || !hasTable || tableName == "" || old == "" || name == "" {
+			return nil, fmt.Errorf("rename tidak valid: %q (format: tabel.kolom_lama=kolom_baru)", spec)
+		}
+		if renames[tableName] == nil {
+			renames[tableName] = make(map[string]string)
+		}
+		renames[tableName][old] = name
+	}
+	return renames, nil
+}
+
+// Generate membuat migration dari perubahan entity lalu menulisnya melalui
+// Writer. ErrNoSchemaChanges dikembalikan jika tidak ada perubahan.
+func (g *MigrationGenerator) Generate() error {
+	set := NewFileSet()
+	if err := g.Render(set); err != nil {
+		return err
+	}
+	return g.writer.Write(set)
+}
+
+// Render me-render migration up/down beserta snapshot skema terbaru ke dalam set
+func (g *MigrationGenerator) Render(set *FileSet) error {
+	if g.databaseType == "" {
+		return errors.New("driver database tidak ditemukan pada go.mod, gunakan --db")
+	}
+	database, err := lookupDatabaseProfile(g.databaseType)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(g.rootDir, migrationsDir)
+	snapshot, ok, err := loadSnapshot(set, dir)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("snapshot skema %s belum ada, jalankan capy migrate snapshot untuk menjadikan entity saat ini sebagai baseline",
+			displayPath(filepath.Join(dir, snapshotFile)))
+	}
+
+	entities, err := parseEntities(filepath.Join(g.rootDir, "internal", "entity"))
+	if err != nil {
+		return err
+	}
+	if err := g.checkRenames(snapshot, entities); err != nil {
+		return err
+	}
+	g.carryReferences(snapshot, entities)
+
+	up := schemaStatements(database.Dialect, snapshot.Tables, entities, g.renames)
+	if len(up) == 0 {
+		return ErrNoSchemaChanges
+	}
+
+	m, err := newMigration(set, dir, g.name, g.now())
+	if err != nil {
+		return err
+	}
+	m.Up = up
+	m.Down = schemaStatements(database.Dialect, entities, snapshot.Tables, invertRenames(g.renames))
+	if err := m.render(set, NewRenderer(g.rootDir, g.modulePath), dir); err != nil {
+		return fmt.Errorf("gagal generate migration: %w", err)
+	}
+
+	snapshot.Tables = entities
+	return snapshot.save(set, dir, m.path(dir, "up"), m.path(dir, "down"))
+}
+
+// GenerateSnapshot menjadikan struct entity saat ini sebagai snapshot skema
+// tanpa membuat migration, untuk proyek yang tabelnya sudah ada
+func (g *MigrationGenerator) GenerateSnapshot() error {
+	dir := filepath.Join(g.rootDir, migrationsDir)
+	set := NewFileSet()
+	snapshot, _, err := loadSnapshot(set, dir)
+	if err != nil {
+		return err
+	}
+
+	entities, err := parseEntities(filepath.Join(g.rootDir, "internal", "entity"))
+	if err != nil {
+		return err
+	}
+	g.carryReferences(snapshot, entities)
+
+	snapshot.Tables = entities
+	if err := snapshot.save(set, dir); err != nil {
+		return err
+	}
+	return g.writer.Write(set)
+}
+
+// checkRenames memastikan kolom yang di-rename ada pada snapshot dan entity
+func (g *MigrationGenerator) checkRenames(snapshot *schemaSnapshot, entities []table) error {
+	current := tablesByName(entities)
+	for tableName, cols := range g.renames {
+		old, ok := snapshot.table(tableName)
+		if !ok {
+			return fmt.Errorf("tabel %s tidak ada pada snapshot skema", tableName)
+		}
+		t := current[tableName]
+		for from, to := range cols {
+			if old.column(from) == nil {
+				return fmt.Errorf("kolom %s.%s tidak ada pada snapshot skema", tableName, from)
+			}
+			if t.column(to) == nil {
+				return fmt.Errorf("kolom %s.%s tidak ada pada entity", tableName, to)
+			}
+		}
+	}
+	return nil
+}
+
+// carryReferences menyalin foreign key beserta aksinya dari snapshot ke tabel
+// hasil parsing entity, karena relasi tersebut tidak tertulis pada tag gorm
+// kolom
+func (g *MigrationGenerator) carryReferences(snapshot *schemaSnapshot, entities []table) {
+	for _, t := range entities {
+		old, ok := snapshot.table(t.Name)
+		if !ok {
+			continue
+		}
+		source := invertMap(g.renames[t.Name])
+		for i, c := range t.Columns {
+			name := c.Name
+			if from, ok := source[name]; ok {
+				name = from
+			}
+			if oc := old.column(name); oc != nil {
+				t.Columns[i].References = oc.References
+				t.Columns[i].OnDelete, t.Columns[i].OnUpdate = oc.OnDelete, oc.OnUpdate
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// migrationsDir adalah direktori migration golang-migrate pada proyek
+const migrationsDir = "migrations"
+
+// migrationVersionFormat adalah format timestamp versi migration (UTC),
+// sama dengan format yang dipakai make migrate-create
+const migrationVersionFormat = "20060102150405"
+
+// migration adalah pasangan file up/down dengan format golang-migrate:
+// <versi>_<nama>.up.sql dan <versi>_<nama>.down.sql
+type migration struct {
+	Version string
+	Name    string
+	Up      []string
+	Down    []string
+}
+
+// migrationData adalah data yang tersedia di template migration
+type migrationData struct {
+	Name       string
+	Direction  string
+	Statements []string
+}
+
+// newMigration membuat migration baru bernama name. Migration yang sudah
+// ada tidak pernah ditimpa karena mungkin sudah dijalankan pada database.
+// Versi baru selalu lebih besar dari versi yang sudah ada di dir maupun di
+// set, karena golang-migrate menolak versi ganda.
+func newMigration(set *FileSet, dir, name string, now time.Time) (*migration, error) {
+	latest, err := latestMigrationVersion(set, dir)
+	if err != nil {
+		return nil, err
+	}
+	t := now.UTC().Truncate(time.Second)
+	if last, err := time.Parse(migrationVersionFormat, latest); err == nil && !t.After(last) {
+		t = last.Add(time.Second)
+	}
+	return &migration{Version: t.Format(migrationVersionFormat), Name: name}, nil
+}
+
+// path mengembalikan path file migration pada dir untuk direction up atau down
+func (m *migration) path(dir, direction string) string {
+	return filepath.Join(dir, fmt.Sprintf("%s_%s.%s.sql", m.Version, m.Name, direction))
+}
+
+// render me-render file up dan down ke dalam set
+func (m *migration) render(set *FileSet, renderer *Renderer, dir string) error {
+	for _, part := range []struct {
+		direction  string
+		statements []string
+	}{{"up", m.Up}, {"down", m.Down}} {
+		path := m.path(dir, part.direction)
+		data := migrationData{Name: m.Name, Direction: part.direction, Statements: part.statements}
+		content, err := renderer.RenderFile("migration/migration.sql.tmpl", path, data)
+		if err != nil {
+			return err
+		}
+		set.Add(path, content)
+	}
+	return nil
+}
+
+// latestMigrationVersion mengembalikan versi terbesar dari file migration
+// pada dir dan pada set
+func latestMigrationVersion(set *FileSet, dir string) (string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return "", fmt.Errorf("gagal membaca direktori %s: %w", dir, err)
+	}
+	for _, f := range set.Files() {
+		if filepath.Dir(f.Path) == filepath.Clean(dir) && filepath.Ext(f.Path) == ".sql" {
+			paths = append(paths, f.Path)
+		}
+	}
+
+	var latest string
+	for _, path := range paths {
+		version, _, _ := strings.Cut(filepath.Base(path), "_")
+		if _, err := time.Parse(migrationVersionFormat, version); err == nil && version > latest {
+			latest = version
+		}
+	}
+	return latest, nil
+}
+
+// findMigration mengembalikan versi migration bernama name pada dir, atau
+// string kosong jika belum ada
+func findMigration(dir, name string) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*_"+name+".up.sql"))
+	if err != nil {
+		return "", fmt.Errorf("gagal membaca direktori %s: %w", dir, err)
+	}
+	for _, match := range matches {
+		version, rest, ok := strings.Cut(filepath.Base(match), "_")
+		if ok && rest == name+".up.sql" {
+			return version, nil
+		}
+	}
+	return "", nil
+}
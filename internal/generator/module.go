@@ -2,322 +2,446 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
-	"text/template"
+	"time"
 )
 
 type ModuleGenerator struct {
-	moduleName  string
-	projectPath string
+	moduleName   string
+	modulePath   string // import path modul Go proyek
+	rootDir      string // direktori root proyek tempat file ditulis
+	databaseType string // dialect migration; kosong berarti tanpa migration
+	httpType     string // framework HTTP; kosong berarti DefaultHTTP
+	http         *httpProfile
+	delivery     []string // delivery modul; nil berarti DefaultDelivery
+	table        TableSpec
+	fields       []Field
+	operations   []Operation // nil berarti route CRUD bawaan
+	dtos         []DTO
+	writer       *Writer
+	now          func() time.Time
 }
 
 func NewModuleGenerator(moduleName string) *ModuleGenerator {
 	return &ModuleGenerator{
-		moduleName:  moduleName,
-		projectPath: "", // Kosongkan nilai awal
+		moduleName: moduleName,
+		writer:     NewWriter(),
+		now:        time.Now,
 	}
 }
 
-// SetProjectPath mengatur path proyek
+// SetProjectPath mengatur path proyek yang dipakai sebagai import path
+// sekaligus direktori root, sesuai struktur yang dibuat oleh capy new
 func (g *ModuleGenerator) SetProjectPath(projectPath string) {
-	g.projectPath = projectPath
+	g.modulePath = projectPath
+	g.rootDir = projectPath
 }
 
-func (g *ModuleGenerator) Generate() error {
-	// Generate model
-	if err := g.generateModel(); err != nil {
-		return fmt.Errorf("gagal generate model: %w", err)
-	}
-
-	// Generate controller
-	if err := g.generateController(); err != nil {
-		return fmt.Errorf("gagal generate controller: %w", err)
-	}
-
-	// Generate repository
-	if err := g.generateRepository(); err != nil {
-		return fmt.Errorf("gagal generate repository: %w", err)
-	}
-
-	// Generate usecase
-	if err := g.generateUsecase(); err != nil {
-		return fmt.Errorf("gagal generate usecase: %w", err)
-	}
-
-	return nil
+// SetModulePath mengatur import path modul Go proyek tanpa mengubah direktori root
+func (g *ModuleGenerator) SetModulePath(modulePath string) {
+	g.modulePath = modulePath
 }
 
-func (g *ModuleGenerator) generateModel() error {
-	template := `package entity
-
-import (
-	"time"
-)
-
-type {{.Name}} struct {
-	ID        uint      ` + "`json:\"id\" gorm:\"primaryKey\"`" + `
-	Username  string    ` + "`json:\"username\" gorm:\"unique;not null\"`" + `
-	Email     string    ` + "`json:\"email\" gorm:\"unique;not null\"`" + `
-	Password  string    ` + "`json:\"password,omitempty\" gorm:\"not null\"`" + `
-	FullName  string    ` + "`json:\"full_name\"`" + `
-	CreatedAt time.Time ` + "`json:\"created_at\"`" + `
-	UpdatedAt time.Time ` + "`json:\"updated_at\"`" + `
-	// TODO: Tambahkan field sesuai kebutuhan
+// SetProject mengatur import path, direktori root, dan database dari hasil
+// DetectProject
+func (g *ModuleGenerator) SetProject(project *Project) {
+	g.modulePath = project.ModulePath
+	g.rootDir = project.Root
+	g.databaseType = project.Database
+	g.httpType = project.HTTP
 }
-`
-	return g.generateFile("internal/entity", g.moduleName+".go", template)
+
+// SetDatabaseType mengatur database yang menentukan dialect SQL migration
+func (g *ModuleGenerator) SetDatabaseType(dbType string) {
+	g.databaseType = dbType
 }
 
-func (g *ModuleGenerator) generateController() error {
-	template := fmt.Sprintf(`package http
+// SetHTTP mengatur framework HTTP yang menentukan handler dan wiring
+// main.go modul, mis. gin
+func (g *ModuleGenerator) SetHTTP(framework string) {
+	g.httpType = framework
+}
 
-import (
-	"encoding/json"
-	"net/http"
-	"strconv"
+// SetDelivery mengatur delivery yang melayani modul, mis. http dan grpc
+func (g *ModuleGenerator) SetDelivery(delivery []string) {
+	g.delivery = delivery
+}
 
-	"%s/internal/entity"
-	"github.com/gorilla/mux"
-)
+// SetFields mengatur field modul hasil ParseFields
+func (g *ModuleGenerator) SetFields(fields []Field) {
+	g.fields = fields
+}
 
-type {{.Name}}Handler struct {
-	usecase {{.Name}}Usecase
+// SetTable mengatur tabel entity yang tidak mengikuti konvensi capy
+func (g *ModuleGenerator) SetTable(spec TableSpec) {
+	g.table = spec
 }
 
-type {{.Name}}Usecase interface {
-	GetAll() ([]entity.{{.Name}}, error)
-	GetByID(id uint) (*entity.{{.Name}}, error)
-	Create({{.LowerName}} *entity.{{.Name}}) error
-	Update({{.LowerName}} *entity.{{.Name}}) error
-	Delete(id uint) error
+// SetOperations mengatur operasi yang dilayani handler modul, mis. hasil
+// membaca spesifikasi OpenAPI. Nil berarti lima route CRUD bawaan.
+func (g *ModuleGenerator) SetOperations(ops []Operation) {
+	g.operations = ops
 }
 
-func New{{.Name}}Handler(usecase {{.Name}}Usecase) *{{.Name}}Handler {
-	return &{{.Name}}Handler{
-		usecase: usecase,
-	}
+// SetDTOs mengatur tipe request/respons tambahan yang dibuat pada internal/dto
+func (g *ModuleGenerator) SetDTOs(dtos []DTO) {
+	g.dtos = dtos
 }
 
-func (h *{{.Name}}Handler) RegisterRoutes(r *mux.Router) {
-	r.HandleFunc("/{{.LowerName}}s", h.GetAll).Methods("GET")
-	r.HandleFunc("/{{.LowerName}}s/{id}", h.GetByID).Methods("GET")
-	r.HandleFunc("/{{.LowerName}}s", h.Create).Methods("POST")
-	r.HandleFunc("/{{.LowerName}}s/{id}", h.Update).Methods("PUT")
-	r.HandleFunc("/{{.LowerName}}s/{id}", h.Delete).Methods("DELETE")
+// SetWriter mengatur Writer yang dipakai Generate, mis. untuk mode dry-run
+func (g *ModuleGenerator) SetWriter(w *Writer) {
+	g.writer = w
 }
 
-func (h *{{.Name}}Handler) GetAll(w http.ResponseWriter, r *http.Request) {
-	items, err := h.usecase.GetAll()
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+// Generate me-render seluruh file modul lalu menulisnya melalui Writer
+func (g *ModuleGenerator) Generate() error {
+	set := NewFileSet()
+	if err := g.Render(set); err != nil {
+		return err
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(items)
+	return g.writer.Write(set)
 }
 
-func (h *{{.Name}}Handler) GetByID(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	id, err := strconv.ParseUint(vars["id"], 10, 32)
+// Render me-render seluruh file modul ke dalam set tanpa menulis ke disk
+func (g *ModuleGenerator) Render(set *FileSet) error {
+	profile, err := lookupHTTPProfile(g.httpType)
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
-		return
+		return err
 	}
-
-	item, err := h.usecase.GetByID(uint(id))
+	g.http = profile
+	delivery, err := parseDelivery(g.delivery)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(item)
-}
-
-func (h *{{.Name}}Handler) Create(w http.ResponseWriter, r *http.Request) {
-	var item entity.{{.Name}}
-	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+	// Generate model
+	if err := g.generateModel(set); err != nil {
+		return fmt.Errorf("gagal generate model: %w", err)
 	}
 
-	if err := h.usecase.Create(&item); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+	// Generate package bersama yang dipakai modul
+	if err := g.generateShared(set); err != nil {
+		return fmt.Errorf("gagal generate package bersama: %w", err)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(item)
-}
-
-func (h *{{.Name}}Handler) Update(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	id, err := strconv.ParseUint(vars["id"], 10, 32)
-	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
-		return
+	// Generate DTO request/respons
+	if err := g.generateDTO(set); err != nil {
+		return fmt.Errorf("gagal generate DTO: %w", err)
 	}
 
-	var item entity.{{.Name}}
-	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+	// Generate controller
+	if delivery.HTTP {
+		if err := g.generateController(set); err != nil {
+			return fmt.Errorf("gagal generate controller: %w", err)
+		}
 	}
 
-	item.ID = uint(id)
-	if err := h.usecase.Update(&item); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+	// Generate server gRPC beserta file .proto
+	if delivery.GRPC {
+		if err := g.generateGRPC(set); err != nil {
+			return fmt.Errorf("gagal generate server gRPC: %w", err)
+		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(item)
-}
+	// Generate skema dan resolver GraphQL
+	if delivery.GraphQL {
+		if err := g.generateGraphQL(set); err != nil {
+			return fmt.Errorf("gagal generate resolver GraphQL: %w", err)
+		}
+	}
 
-func (h *{{.Name}}Handler) Delete(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	id, err := strconv.ParseUint(vars["id"], 10, 32)
-	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
-		return
+	// Generate repository
+	if err := g.generateRepository(set); err != nil {
+		return fmt.Errorf("gagal generate repository: %w", err)
 	}
 
-	if err := h.usecase.Delete(uint(id)); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+	// Generate usecase
+	if err := g.generateUsecase(set); err != nil {
+		return fmt.Errorf("gagal generate usecase: %w", err)
 	}
 
-	w.WriteHeader(http.StatusNoContent)
-}
-`, g.projectPath)
-	return g.generateFile("internal/delivery/http", g.moduleName+"_handler.go", template)
-}
+	// Perbarui spesifikasi OpenAPI proyek
+	if delivery.HTTP {
+		if err := g.generateOpenAPI(set); err != nil {
+			return fmt.Errorf("gagal generate spesifikasi OpenAPI: %w", err)
+		}
+	}
 
-func (g *ModuleGenerator) generateRepository() error {
-	template := fmt.Sprintf(`package repository
+	// Generate migration
+	if g.databaseType != "" {
+		if err := g.generateMigration(set); err != nil {
+			return fmt.Errorf("gagal generate migration: %w", err)
+		}
+	}
 
-import (
-	"%s/internal/entity"
-	"gorm.io/gorm"
-)
+	// Register model di AutoMigrate serta routes, server gRPC, dan resolver
+	// GraphQL di main.go
+	if err := g.register(set, delivery); err != nil {
+		return err
+	}
 
-type {{.Name}}Repository struct {
-	db *gorm.DB
+	return nil
 }
 
-func New{{.Name}}Repository(db *gorm.DB) *{{.Name}}Repository {
-	return &{{.Name}}Repository{
-		db: db,
+func (g *ModuleGenerator) generateModel(set *FileSet) error {
+	return g.generateFile(set, "internal/entity", g.moduleName+".go", "module/entity.go.tmpl")
+}
+
+// sharedFiles adalah file bersama yang dipakai kode seluruh modul
+var sharedFiles = []struct{ path, tmpl string }{
+	{"pkg/validation/validation.go", "pkg/validation.go.tmpl"},
+	{"pkg/apperror/apperror.go", "pkg/apperror.go.tmpl"},
+	{"pkg/query/query.go", "pkg/query.go.tmpl"},
+	{"internal/repository/errors.go", "module/errors.go.tmpl"},
+	{"internal/repository/list.go", "module/list.go.tmpl"},
+	{"internal/delivery/http/problem.go", "module/problem.go.tmpl"},
+}
+
+// generateShared membuat file bersama yang belum ada. File yang sudah
+// ada tidak diubah karena dapat berisi perubahan user.
+func (g *ModuleGenerator) generateShared(set *FileSet) error {
+	for _, f := range sharedFiles {
+		path := filepath.Join(g.rootDir, filepath.FromSlash(f.path))
+		_, exists, err := currentContent(set, path)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		dir, name := filepath.Split(filepath.FromSlash(f.path))
+		if err := g.generateFile(set, dir, name, f.tmpl); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-func (r *{{.Name}}Repository) GetAll() ([]entity.{{.Name}}, error) {
-	var items []entity.{{.Name}}
-	result := r.db.Find(&items)
-	return items, result.Error
+func (g *ModuleGenerator) generateDTO(set *FileSet) error {
+	reserved := moduleDTONames(toPascal(g.moduleName))
+	for _, dto := range g.dtos {
+		for _, name := range reserved {
+			if dto.Name == name {
+				return fmt.Errorf("DTO %s bentrok dengan DTO bawaan modul %s", dto.Name, g.moduleName)
+			}
+		}
+	}
+	return g.generateFile(set, "internal/dto", g.moduleName+".go", "module/dto.go.tmpl")
 }
 
-func (r *{{.Name}}Repository) GetByID(id uint) (*entity.{{.Name}}, error) {
-	var item entity.{{.Name}}
-	result := r.db.First(&item, id)
-	return &item, result.Error
+func (g *ModuleGenerator) generateController(set *FileSet) error {
+	return g.generateFile(set, "internal/delivery/http", g.moduleName+"_handler.go", g.http.Handler)
 }
 
-func (r *{{.Name}}Repository) Create({{.LowerName}} *entity.{{.Name}}) error {
-	return r.db.Create({{.LowerName}}).Error
+func (g *ModuleGenerator) generateRepository(set *FileSet) error {
+	return g.generateFile(set, "internal/repository", g.moduleName+"_repository.go", "module/repository.go.tmpl")
 }
 
-func (r *{{.Name}}Repository) Update({{.LowerName}} *entity.{{.Name}}) error {
-	return r.db.Save({{.LowerName}}).Error
+func (g *ModuleGenerator) generateUsecase(set *FileSet) error {
+	return g.generateFile(set, "internal/usecase", g.moduleName+"_usecase.go", "module/usecase.go.tmpl")
 }
 
-func (r *{{.Name}}Repository) Delete(id uint) error {
-	return r.db.Delete(&entity.{{.Name}}{}, id).Error
-}
-`, g.projectPath)
-	return g.generateFile("internal/repository", g.moduleName+"_repository.go", template)
-}
+// generateMigration membuat migration golang-migrate yang membuat dan
+// menghapus tabel modul dengan DDL sesuai dialect database proyek, lalu
+// mencatat tabel tersebut pada snapshot skema
+func (g *ModuleGenerator) generateMigration(set *FileSet) error {
+	database, err := lookupDatabaseProfile(g.databaseType)
+	if err != nil {
+		return err
+	}
 
-func (g *ModuleGenerator) generateUsecase() error {
-	template := fmt.Sprintf(`package usecase
+	t := tableFromFields(g.moduleName, g.table, g.fields)
+	dir := filepath.Join(g.rootDir, migrationsDir)
 
-import (
-	"%s/internal/entity"
-)
+	// Migration dan snapshot hanya ditulis jika entity ikut ditulis, mis.
+	// tidak ketika entity dilewati dengan --skip-existing
+	requires := []string{filepath.Join(g.rootDir, "internal", "entity", g.moduleName+".go")}
+	snapshot, _, err := loadSnapshot(set, dir)
+	if err != nil {
+		return err
+	}
+	if !g.table.Exists {
+		m, err := g.tableMigration(set, database.Dialect, snapshot, t, dir)
+		if err != nil {
+			return err
+		}
+		if m != nil {
+			if err := m.render(set, NewRenderer(g.rootDir, g.modulePath), dir); err != nil {
+				return err
+			}
+			for _, direction := range []string{"up", "down"} {
+				set.Require(m.path(dir, direction), requires[0])
+				requires = append(requires, m.path(dir, direction))
+			}
+		}
+	}
 
-type {{.Name}}Usecase struct {
-	repo {{.Name}}Repository
+	// Simpan tabel modul ke snapshot sebagai pembanding capy migrate diff
+	snapshot.put(t)
+	return snapshot.save(set, dir, requires...)
 }
 
-type {{.Name}}Repository interface {
-	GetAll() ([]entity.{{.Name}}, error)
-	GetByID(id uint) (*entity.{{.Name}}, error)
-	Create({{.LowerName}} *entity.{{.Name}}) error
-	Update({{.LowerName}} *entity.{{.Name}}) error
-	Delete(id uint) error
-}
+// tableMigration membuat migration create tabel t. Migration create yang
+// sudah ada tidak ditulis ulang karena mungkin sudah dijalankan; perubahan
+// tabel dibuat sebagai migration alter terhadap snapshot skema seperti capy
+// migrate diff. Nil dikembalikan jika tabel tidak berubah.
+func (g *ModuleGenerator) tableMigration(set *FileSet, d *sqlDialect, snapshot *schemaSnapshot, t table, dir string) (*migration, error) {
+	create := "create_" + t.Name + "_table"
+	version, err := findMigration(dir, create)
+	if err != nil {
+		return nil, err
+	}
+	if version == "" {
+		m, err := newMigration(set, dir, create, g.now())
+		if err != nil {
+			return nil, err
+		}
+		m.Up = d.createTable(t)
+		m.Down = []string{d.dropTable(t.Name)}
+		return m, nil
+	}
 
-func New{{.Name}}Usecase(repo {{.Name}}Repository) *{{.Name}}Usecase {
-	return &{{.Name}}Usecase{
-		repo: repo,
+	old, ok := snapshot.table(t.Name)
+	if !ok {
+		return nil, fmt.Errorf("migration %s_%s sudah ada tetapi tabel %s tidak ada pada snapshot skema, jalankan capy migrate snapshot lalu capy migrate diff",
+			version, create, t.Name)
+	}
+	up := d.alterTable(old, t, nil)
+	if len(up) == 0 {
+		return nil, nil
+	}
+	m, err := newMigration(set, dir, "alter_"+t.Name+"_table", g.now())
+	if err != nil {
+		return nil, err
 	}
+	m.Up = up
+	m.Down = d.alterTable(t, old, nil)
+	return m, nil
+}
+
+func (g *ModuleGenerator) register(set *FileSet, delivery deliveries) error {
+	r := &registrar{
+		set:        set,
+		rootDir:    g.rootDir,
+		modulePath: g.modulePath,
+		name:       toPascal(g.moduleName),
+		varPrefix:  toCamel(g.moduleName),
+		http:       g.http,
+		delivery:   delivery,
+
+		skipAutoMigrate: g.table.Exists,
+	}
+	return r.register()
+}
+
+// moduleData adalah data yang tersedia di template modul. TableName dan
+// PrimaryKey hanya terisi jika berbeda dari konvensi GORM.
+type moduleData struct {
+	Name       string
+	LowerName  string
+	ModulePath string
+	Fields     []Field
+	TableName  string
+	PrimaryKey string
+	Timestamps bool
+	Operations []Operation
+	DTOs       []DTO
+	HTTP       *httpProfile
+}
+
+// Operation mengembalikan operasi bernama name, atau nil jika handler tidak
+// melayaninya
+func (d moduleData) Operation(name string) *Operation {
+	for i := range d.Operations {
+		if d.Operations[i].Name == name {
+			return &d.Operations[i]
+		}
+	}
+	return nil
 }
 
-func (u *{{.Name}}Usecase) GetAll() ([]entity.{{.Name}}, error) {
-	return u.repo.GetAll()
+// RequestFields mengembalikan field yang diterima pada request create dan update
+func (d moduleData) RequestFields() []Field {
+	var fields []Field
+	for _, f := range d.Fields {
+		if f.InRequest() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
 }
 
-func (u *{{.Name}}Usecase) GetByID(id uint) (*entity.{{.Name}}, error) {
-	return u.repo.GetByID(id)
+// WriteOnlyFields mengembalikan field writeonly pada request
+func (d moduleData) WriteOnlyFields() []Field {
+	var fields []Field
+	for _, f := range d.RequestFields() {
+		if f.WriteOnly {
+			fields = append(fields, f)
+		}
+	}
+	return fields
 }
 
-func (u *{{.Name}}Usecase) Create({{.LowerName}} *entity.{{.Name}}) error {
-	// TODO: Add validation
-	return u.repo.Create({{.LowerName}})
+// UpdateColumns mengembalikan kolom yang selalu diperbarui request update,
+// yaitu kolom field request selain writeonly
+func (d moduleData) UpdateColumns() []string {
+	var columns []string
+	for _, f := range d.RequestFields() {
+		if !f.WriteOnly {
+			columns = append(columns, f.Column)
+		}
+	}
+	return columns
 }
 
-func (u *{{.Name}}Usecase) Update({{.LowerName}} *entity.{{.Name}}) error {
-	// TODO: Add validation
-	return u.repo.Update({{.LowerName}})
+// ResponseFields mengembalikan field yang dikirim pada respons
+func (d moduleData) ResponseFields() []Field {
+	var fields []Field
+	for _, f := range d.Fields {
+		if f.InResponse() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
 }
 
-func (u *{{.Name}}Usecase) Delete(id uint) error {
-	return u.repo.Delete(id)
-}
-`, g.projectPath)
-	return g.generateFile("internal/usecase", g.moduleName+"_usecase.go", template)
+// moduleDTONames adalah nama DTO request/respons yang selalu dibuat untuk
+// entity name
+func moduleDTONames(name string) []string {
+	return []string{"Create" + name + "Request", "Update" + name + "Request", name + "Response", name + "ListResponse"}
 }
 
-func (g *ModuleGenerator) generateFile(dir, filename, tmpl string) error {
-	fullDir := filepath.Join(g.projectPath, dir)
-	if err := os.MkdirAll(fullDir, 0755); err != nil {
-		return fmt.Errorf("gagal membuat direktori %s: %w", fullDir, err)
+func (g *ModuleGenerator) templateData() moduleData {
+	data := moduleData{
+		Name:       toPascal(g.moduleName),
+		LowerName:  strings.ToLower(g.moduleName),
+		ModulePath: g.modulePath,
+		Fields:     g.fields,
+		Timestamps: !g.table.NoTimestamps,
+		Operations: g.operations,
+		DTOs:       g.dtos,
+		HTTP:       g.http,
 	}
-
-	t, err := template.New("module").Parse(tmpl)
-	if err != nil {
-		return fmt.Errorf("gagal parse template: %w", err)
+	if data.Operations == nil {
+		data.Operations = defaultOperations(data.LowerName)
 	}
-
-	file, err := os.Create(filepath.Join(fullDir, filename))
-	if err != nil {
-		return fmt.Errorf("gagal membuat file: %s: %w", filepath.Join(fullDir, filename), err)
+	if t := g.table.table(g.moduleName); t != tableName(g.moduleName) {
+		data.TableName = t
+	}
+	if pk := g.table.primaryKey(); pk != "id" {
+		data.PrimaryKey = pk
 	}
-	defer file.Close()
+	return data
+}
 
-	data := struct {
-		Name        string
-		LowerName   string
-		ProjectPath string
-	}{
-		Name:        strings.Title(g.moduleName),
-		LowerName:   strings.ToLower(g.moduleName),
-		ProjectPath: g.projectPath,
+func (g *ModuleGenerator) generateFile(set *FileSet, dir, filename, tmpl string) error {
+	path := filepath.Join(g.rootDir, dir, filename)
+	content, err := NewRenderer(g.rootDir, g.modulePath).RenderFile(tmpl, path, g.templateData())
+	if err != nil {
+		return err
 	}
 
-	return t.Execute(file, data)
+	set.Add(path, content)
+	return nil
 }
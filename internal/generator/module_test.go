@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// generateModule menjalankan ModuleGenerator pada proyek sqlite di root
+// dengan mode konflik conflict
+func generateModule(t *testing.T, root, name string, conflict ConflictMode, now time.Time, specs ...string) {
+	t.Helper()
+	fields, err := ParseFields(specs)
+	if err != nil {
+		t.Fatalf("ParseFields: %v", err)
+	}
+	g := NewModuleGenerator(name)
+	g.SetProject(&Project{Root: root, ModulePath: "example.com/shop", Database: "sqlite"})
+	g.SetFields(fields)
+	g.SetWriter(&Writer{Conflict: conflict, Out: io.Discard})
+	g.now = func() time.Time { return now }
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func migrationFiles(t *testing.T, root string) []string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(root, migrationsDir, "*.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return paths
+}
+
+// Entity yang dilewati dengan --skip-existing tidak boleh membuat snapshot
+// mencatat kolom yang tidak pernah dibuat migration-nya
+func TestModuleSkipExistingKeepsSnapshot(t *testing.T) {
+	root := t.TempDir()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	generateModule(t, root, "product", ConflictOverwrite, now, "name:string", "price:decimal")
+
+	entityPath := filepath.Join(root, "internal", "entity", "product.go")
+	entity := readFile(t, entityPath) + "\n// diubah user\n"
+	if err := os.WriteFile(entityPath, []byte(entity), 0644); err != nil {
+		t.Fatal(err)
+	}
+	snapshotPath := filepath.Join(root, migrationsDir, snapshotFile)
+	snapshot := readFile(t, snapshotPath)
+	migrations := migrationFiles(t, root)
+
+	generateModule(t, root, "product", ConflictSkip, now.Add(time.Hour), "name:string", "price:decimal", "stock:int")
+
+	if got := readFile(t, entityPath); got != entity {
+		t.Errorf("entity ditimpa walaupun --skip-existing")
+	}
+	if got := readFile(t, snapshotPath); got != snapshot {
+		t.Errorf("snapshot berubah walaupun entity dilewati:\n%s", got)
+	}
+	if got := migrationFiles(t, root); len(got) != len(migrations) {
+		t.Errorf("migration = %v, want %v", got, migrations)
+	}
+}
+
+// Snapshot ditulis bersama entity dan migration jika keduanya ditulis
+func TestModuleWritesSnapshot(t *testing.T) {
+	root := t.TempDir()
+	generateModule(t, root, "product", ConflictOverwrite, time.Now(), "name:string")
+
+	snapshot, ok, err := loadSnapshot(NewFileSet(), filepath.Join(root, migrationsDir))
+	if err != nil || !ok {
+		t.Fatalf("loadSnapshot: ok=%v err=%v", ok, err)
+	}
+	products, ok := snapshot.table("products")
+	if !ok || products.column("name") == nil {
+		t.Errorf("snapshot tidak mencatat products.name: %+v", snapshot.Tables)
+	}
+	if got := migrationFiles(t, root); len(got) != 2 {
+		t.Errorf("migration = %v, want up dan down", got)
+	}
+}
+
+// Generate ulang modul dengan --force tidak boleh menulis ulang migration
+// create yang mungkin sudah dijalankan, melainkan membuat migration alter
+func TestModuleRegenerateKeepsCreateMigration(t *testing.T) {
+	root := t.TempDir()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	generateModule(t, root, "product", ConflictOverwrite, now, "name:string", "price:decimal", "stock:int")
+
+	createUp := filepath.Join(root, migrationsDir, "20240102030405_create_products_table.up.sql")
+	create := readFile(t, createUp)
+
+	generateModule(t, root, "product", ConflictOverwrite, now.Add(time.Hour), "name:string", "price:decimal")
+	if got := readFile(t, createUp); got != create {
+		t.Errorf("migration create ditulis ulang:\n%s", got)
+	}
+	alterUp := filepath.Join(root, migrationsDir, "20240102040405_alter_products_table.up.sql")
+	if got := readFile(t, alterUp); !strings.Contains(got, `DROP COLUMN "stock"`) {
+		t.Errorf("migration alter tidak menghapus stock:\n%s", got)
+	}
+
+	// Field yang sama tidak menghasilkan migration baru
+	generateModule(t, root, "product", ConflictOverwrite, now.Add(2*time.Hour), "name:string", "price:decimal")
+	if got := migrationFiles(t, root); len(got) != 4 {
+		t.Errorf("migration = %v, want create dan alter", got)
+	}
+}
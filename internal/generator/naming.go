@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/jinzhu/inflection"
+)
+
+// commonInitialisms berisi singkatan yang ditulis kapital penuh pada nama Go
+var commonInitialisms = map[string]bool{
+	"API":  true,
+	"DB":   true,
+	"HTML": true,
+	"HTTP": true,
+	"ID":   true,
+	"IP":   true,
+	"JSON": true,
+	"SQL":  true,
+	"URL":  true,
+	"UUID": true,
+}
+
+// splitWords memecah nama snake_case, kebab-case, atau camelCase menjadi kata-kata
+func splitWords(s string) []string {
+	var words []string
+	var current []rune
+
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == ' ' || r == '.':
+			if len(current) > 0 {
+				words = append(words, string(current))
+				current = nil
+			}
+			continue
+		case unicode.IsUpper(r) && len(current) > 0:
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				words = append(words, string(current))
+				current = nil
+			}
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+	return words
+}
+
+// toPascal mengubah nama menjadi PascalCase, mis. category_id menjadi CategoryID
+func toPascal(s string) string {
+	var b strings.Builder
+	for _, w := range splitWords(s) {
+		upper := strings.ToUpper(w)
+		if commonInitialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + strings.ToLower(w[1:]))
+	}
+	return b.String()
+}
+
+// toCamel mengubah nama menjadi camelCase, mis. category_id menjadi categoryID
+func toCamel(s string) string {
+	words := splitWords(s)
+	if len(words) == 0 {
+		return ""
+	}
+	first := strings.ToLower(words[0])
+	return first + toPascal(strings.Join(words[1:], "_"))
+}
+
+// toSnake mengubah nama menjadi snake_case, mis. CategoryID menjadi category_id
+func toSnake(s string) string {
+	words := splitWords(s)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	return strings.Join(words, "_")
+}
+
+// tableName mengikuti konvensi penamaan tabel GORM: snake_case jamak
+func tableName(name string) string {
+	return inflection.Plural(toSnake(name))
+}
@@ -0,0 +1,346 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIDoc adalah bagian spesifikasi OpenAPI 3 yang dipakai capy
+type openAPIDoc struct {
+	OpenAPI    string                    `yaml:"openapi"`
+	Info       openAPIInfo               `yaml:"info"`
+	Paths      yamlMap[*openAPIPathItem] `yaml:"paths"`
+	Components openAPIComponents         `yaml:"components,omitempty"`
+}
+
+type openAPIInfo struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description,omitempty"`
+	Version     string `yaml:"version"`
+}
+
+type openAPIComponents struct {
+	Schemas       yamlMap[*openAPISchema]      `yaml:"schemas,omitempty"`
+	Parameters    yamlMap[*openAPIParameter]   `yaml:"parameters,omitempty"`
+	RequestBodies yamlMap[*openAPIRequestBody] `yaml:"requestBodies,omitempty"`
+	Responses     yamlMap[*openAPIResponse]    `yaml:"responses,omitempty"`
+}
+
+type openAPIPathItem struct {
+	Parameters []*openAPIParameter `yaml:"parameters,omitempty"`
+	Get        *openAPIOperation   `yaml:"get,omitempty"`
+	Post       *openAPIOperation   `yaml:"post,omitempty"`
+	Put        *openAPIOperation   `yaml:"put,omitempty"`
+	Patch      *openAPIOperation   `yaml:"patch,omitempty"`
+	Delete     *openAPIOperation   `yaml:"delete,omitempty"`
+}
+
+// operations mengembalikan operasi path item beserta method HTTP-nya
+func (p *openAPIPathItem) operations() []methodOperation {
+	var ops []methodOperation
+	for _, op := range []methodOperation{
+		{"GET", p.Get}, {"POST", p.Post}, {"PUT", p.Put}, {"PATCH", p.Patch}, {"DELETE", p.Delete},
+	} {
+		if op.Operation != nil {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+type methodOperation struct {
+	Method    string
+	Operation *openAPIOperation
+}
+
+type openAPIOperation struct {
+	OperationID string                    `yaml:"operationId,omitempty"`
+	Summary     string                    `yaml:"summary,omitempty"`
+	Tags        []string                  `yaml:"tags,omitempty"`
+	Parameters  []*openAPIParameter       `yaml:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody       `yaml:"requestBody,omitempty"`
+	Responses   yamlMap[*openAPIResponse] `yaml:"responses"`
+}
+
+type openAPIParameter struct {
+	Ref         string         `yaml:"$ref,omitempty"`
+	Name        string         `yaml:"name,omitempty"`
+	In          string         `yaml:"in,omitempty"`
+	Description string         `yaml:"description,omitempty"`
+	Required    bool           `yaml:"required,omitempty"`
+	Schema      *openAPISchema `yaml:"schema,omitempty"`
+}
+
+type openAPIRequestBody struct {
+	Ref      string                       `yaml:"$ref,omitempty"`
+	Required bool                         `yaml:"required,omitempty"`
+	Content  map[string]*openAPIMediaType `yaml:"content,omitempty"`
+}
+
+type openAPIResponse struct {
+	Ref         string                       `yaml:"$ref,omitempty"`
+	Description string                       `yaml:"description,omitempty"`
+	Headers     map[string]*openAPIHeader    `yaml:"headers,omitempty"`
+	Content     map[string]*openAPIMediaType `yaml:"content,omitempty"`
+}
+
+type openAPIHeader struct {
+	Description string         `yaml:"description,omitempty"`
+	Schema      *openAPISchema `yaml:"schema,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `yaml:"schema,omitempty"`
+}
+
+// jsonSchema mengembalikan schema konten application/json atau konten JSON
+// lain, mis. application/problem+json
+func jsonSchema(content map[string]*openAPIMediaType) *openAPISchema {
+	if m := content["application/json"]; m != nil {
+		return m.Schema
+	}
+	for _, key := range sortedKeys(content) {
+		if strings.Contains(key, "json") && content[key] != nil {
+			return content[key].Schema
+		}
+	}
+	return nil
+}
+
+type openAPISchema struct {
+	Ref         string                  `yaml:"$ref,omitempty"`
+	Type        schemaType              `yaml:"type,omitempty"`
+	Format      string                  `yaml:"format,omitempty"`
+	Description string                  `yaml:"description,omitempty"`
+	Properties  yamlMap[*openAPISchema] `yaml:"properties,omitempty"`
+	Required    []string                `yaml:"required,omitempty"`
+	Items       *openAPISchema          `yaml:"items,omitempty"`
+	AllOf       []*openAPISchema        `yaml:"allOf,omitempty"`
+	Nullable    bool                    `yaml:"nullable,omitempty"`
+	ReadOnly    bool                    `yaml:"readOnly,omitempty"`
+	WriteOnly   bool                    `yaml:"writeOnly,omitempty"`
+	Default     interface{}             `yaml:"default,omitempty"`
+	Enum        []interface{}           `yaml:"enum,omitempty"`
+	MinLength   *int                    `yaml:"minLength,omitempty"`
+	MaxLength   *int                    `yaml:"maxLength,omitempty"`
+	Minimum     *float64                `yaml:"minimum,omitempty"`
+	Maximum     *float64                `yaml:"maximum,omitempty"`
+	Pattern     string                  `yaml:"pattern,omitempty"`
+
+	// Entity memaksa schema dianggap entity (true) atau DTO (false)
+	Entity *bool `yaml:"x-capy-entity,omitempty"`
+}
+
+// required melaporkan apakah properti name wajib ada
+func (s *openAPISchema) required(name string) bool {
+	for _, r := range s.Required {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaType adalah tipe schema. OpenAPI 3.1 mengizinkan daftar tipe,
+// mis. [string, "null"], yang dipetakan menjadi tipe nullable.
+type schemaType struct {
+	Name string
+	Null bool
+}
+
+func (t *schemaType) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		t.Name = node.Value
+		return nil
+	}
+	var names []string
+	if err := node.Decode(&names); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if name == "null" {
+			t.Null = true
+		} else if t.Name == "" {
+			t.Name = name
+		}
+	}
+	return nil
+}
+
+func (t schemaType) MarshalYAML() (interface{}, error) {
+	if t.Null {
+		return []string{t.Name, "null"}, nil
+	}
+	return t.Name, nil
+}
+
+func (t schemaType) IsZero() bool {
+	return t.Name == "" && !t.Null
+}
+
+// yamlMap adalah map YAML yang mempertahankan urutan key, mis. urutan
+// properti schema yang menjadi urutan field entity
+type yamlMap[T any] []yamlEntry[T]
+
+type yamlEntry[T any] struct {
+	Key   string
+	Value T
+}
+
+func (m *yamlMap[T]) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("baris %d: diharapkan map", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var value T
+		if err := node.Content[i+1].Decode(&value); err != nil {
+			return err
+		}
+		*m = append(*m, yamlEntry[T]{Key: node.Content[i].Value, Value: value})
+	}
+	return nil
+}
+
+func (m yamlMap[T]) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, e := range m {
+		var value yaml.Node
+		if err := value.Encode(e.Value); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: e.Key}, &value)
+	}
+	return node, nil
+}
+
+// get mengembalikan nilai untuk key
+func (m yamlMap[T]) get(key string) (T, bool) {
+	for _, e := range m {
+		if e.Key == key {
+			return e.Value, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// loadOpenAPI membaca spesifikasi OpenAPI 3 dalam format YAML atau JSON
+func loadOpenAPI(path string) (*openAPIDoc, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca %s: %w", path, err)
+	}
+	var doc openAPIDoc
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("gagal parse %s: %w", path, err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("%s bukan spesifikasi OpenAPI 3 (openapi: %q)", path, doc.OpenAPI)
+	}
+	return &doc, nil
+}
+
+// refName mengembalikan nama komponen pada $ref, mis. #/components/schemas/Order
+// menjadi Order. Hanya referensi lokal yang didukung.
+func refName(ref, kind string) (string, error) {
+	prefix := "#/components/" + kind + "/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", fmt.Errorf("$ref %q tidak didukung (hanya %s...)", ref, prefix)
+	}
+	return strings.TrimPrefix(ref, prefix), nil
+}
+
+// schema mengembalikan schema setelah $ref diikuti
+func (d *openAPIDoc) schema(s *openAPISchema) (*openAPISchema, error) {
+	for seen := 0; s != nil && s.Ref != ""; seen++ {
+		if seen > 32 {
+			return nil, fmt.Errorf("$ref %q melingkar", s.Ref)
+		}
+		name, err := refName(s.Ref, "schemas")
+		if err != nil {
+			return nil, err
+		}
+		target, ok := d.Components.Schemas.get(name)
+		if !ok {
+			return nil, fmt.Errorf("schema %s tidak ditemukan", name)
+		}
+		s = target
+	}
+	return s, nil
+}
+
+// flatten menggabungkan properti dan required dari allOf menjadi satu schema
+func (d *openAPIDoc) flatten(s *openAPISchema) (*openAPISchema, error) {
+	s, err := d.schema(s)
+	if err != nil || s == nil || len(s.AllOf) == 0 {
+		return s, err
+	}
+	merged := *s
+	merged.AllOf = nil
+	merged.Properties = nil
+	merged.Required = append([]string(nil), s.Required...)
+	for _, part := range s.AllOf {
+		part, err := d.flatten(part)
+		if err != nil {
+			return nil, err
+		}
+		if merged.Type.Name == "" {
+			merged.Type = part.Type
+		}
+		merged.Properties = append(merged.Properties, part.Properties...)
+		merged.Required = append(merged.Required, part.Required...)
+	}
+	merged.Properties = append(merged.Properties, s.Properties...)
+	return &merged, nil
+}
+
+// parameter mengembalikan parameter setelah $ref diikuti
+func (d *openAPIDoc) parameter(p *openAPIParameter) (*openAPIParameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+	name, err := refName(p.Ref, "parameters")
+	if err != nil {
+		return nil, err
+	}
+	target, ok := d.Components.Parameters.get(name)
+	if !ok {
+		return nil, fmt.Errorf("parameter %s tidak ditemukan", name)
+	}
+	return target, nil
+}
+
+// requestBody mengembalikan request body setelah $ref diikuti
+func (d *openAPIDoc) requestBody(b *openAPIRequestBody) (*openAPIRequestBody, error) {
+	if b == nil || b.Ref == "" {
+		return b, nil
+	}
+	name, err := refName(b.Ref, "requestBodies")
+	if err != nil {
+		return nil, err
+	}
+	target, ok := d.Components.RequestBodies.get(name)
+	if !ok {
+		return nil, fmt.Errorf("request body %s tidak ditemukan", name)
+	}
+	return target, nil
+}
+
+// response mengembalikan response setelah $ref diikuti
+func (d *openAPIDoc) response(r *openAPIResponse) (*openAPIResponse, error) {
+	if r == nil || r.Ref == "" {
+		return r, nil
+	}
+	name, err := refName(r.Ref, "responses")
+	if err != nil {
+		return nil, err
+	}
+	target, ok := d.Components.Responses.get(name)
+	if !ok {
+		return nil, fmt.Errorf("response %s tidak ditemukan", name)
+	}
+	return target, nil
+}
@@ -0,0 +1,513 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jinzhu/inflection"
+	"gopkg.in/yaml.v3"
+)
+
+// openAPISpecFile adalah spesifikasi OpenAPI proyek yang diperbarui setiap
+// kali modul dibuat
+const openAPISpecFile = "api/openapi.yaml"
+
+// generateOpenAPI menambahkan path, parameter, request body, dan schema
+// respons modul ke api/openapi.yaml. File diolah sebagai node YAML sehingga
+// bagian yang ditulis user (servers, security, contoh, ...) tetap utuh;
+// hanya operasi dan schema modul ini yang diganti.
+func (g *ModuleGenerator) generateOpenAPI(set *FileSet) error {
+	specPath := filepath.Join(g.rootDir, filepath.FromSlash(openAPISpecFile))
+	src, ok, err := currentContent(set, specPath)
+	if err != nil {
+		return err
+	}
+
+	var root yaml.Node
+	if ok {
+		if err := yaml.Unmarshal(src, &root); err != nil {
+			return fmt.Errorf("gagal parse %s: %w", openAPISpecFile, err)
+		}
+	}
+	if len(root.Content) == 0 {
+		doc := openAPIDoc{
+			OpenAPI: "3.0.3",
+			Info:    openAPIInfo{Title: path.Base(g.modulePath) + " API", Version: "1.0.0"},
+		}
+		root = yaml.Node{Kind: yaml.DocumentNode}
+		node, err := yamlNode(doc)
+		if err != nil {
+			return err
+		}
+		root.Content = []*yaml.Node{node}
+	}
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s bukan spesifikasi OpenAPI yang valid", openAPISpecFile)
+	}
+
+	data := g.templateData()
+	paths := yamlChild(doc, "paths")
+	for _, op := range data.Operations {
+		node, err := yamlNode(openAPIOperationFor(data, op))
+		if err != nil {
+			return err
+		}
+		yamlSet(yamlChild(paths, op.Path), strings.ToLower(op.Method), node)
+	}
+
+	schemas := yamlChild(yamlChild(doc, "components"), "schemas")
+	names := moduleDTONames(data.Name)
+	components := yamlMap[*openAPISchema]{
+		{names[0], requestOpenAPISchema(data, false)},
+		{names[1], requestOpenAPISchema(data, true)},
+		{names[2], responseOpenAPISchema(data)},
+		{names[3], listOpenAPISchema(data)},
+		{"PaginationMeta", paginationOpenAPISchema()},
+		{"Problem", problemOpenAPISchema()},
+	}
+	for _, dto := range data.DTOs {
+		components = append(components, yamlEntry[*openAPISchema]{dto.Name, dtoOpenAPISchema(dto)})
+	}
+	for _, c := range components {
+		node, err := yamlNode(c.Value)
+		if err != nil {
+			return err
+		}
+		yamlSet(schemas, c.Key, node)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&root); err != nil {
+		return fmt.Errorf("gagal menulis %s: %w", openAPISpecFile, err)
+	}
+	set.Update(specPath, buf.Bytes())
+	return nil
+}
+
+// openAPIOperationFor mendeskripsikan operasi handler modul
+func openAPIOperationFor(data moduleData, op Operation) *openAPIOperation {
+	names := moduleDTONames(data.Name)
+	createRef := &openAPISchema{Ref: "#/components/schemas/" + names[0]}
+	updateRef := &openAPISchema{Ref: "#/components/schemas/" + names[1]}
+	responseRef := &openAPISchema{Ref: "#/components/schemas/" + names[2]}
+	listRef := &openAPISchema{Ref: "#/components/schemas/" + names[3]}
+	plural := toPascal(inflection.Plural(data.LowerName))
+	out := &openAPIOperation{
+		OperationID: toCamel(op.Name),
+		Summary:     op.Summary,
+		Tags:        []string{data.Name},
+	}
+	for _, p := range op.Params {
+		schema := &openAPISchema{Type: schemaType{Name: "string"}}
+		if p.GoType != "string" {
+			schema.Type.Name = "integer"
+		}
+		out.Parameters = append(out.Parameters, &openAPIParameter{Name: p.Name, In: "path", Required: true, Schema: schema})
+	}
+
+	var request, response *openAPISchema
+	var summary string
+	switch op.Name {
+	case "GetAll":
+		out.OperationID, response = "list"+plural, listRef
+		if op.ListArray {
+			response = &openAPISchema{Type: schemaType{Name: "array"}, Items: responseRef}
+		}
+		out.Parameters = append(out.Parameters, listOpenAPIParameters(data)...)
+		summary = "Daftar semua " + data.LowerName
+	case "GetByID":
+		out.OperationID, response = "get"+data.Name, responseRef
+		summary = "Ambil " + data.LowerName + " berdasarkan ID"
+	case "Create":
+		out.OperationID, request, response = "create"+data.Name, createRef, responseRef
+		if op.Request != "" {
+			request = goTypeOpenAPISchema(op.Request)
+		}
+		summary = "Buat " + data.LowerName + " baru"
+	case "Update":
+		out.OperationID, request, response = "update"+data.Name, updateRef, responseRef
+		if op.Request != "" {
+			request = goTypeOpenAPISchema(op.Request)
+		}
+		summary = "Perbarui " + data.LowerName
+	case "Delete":
+		out.OperationID = "delete" + data.Name
+		summary = "Hapus " + data.LowerName
+	default:
+		if op.Request != "" {
+			request = goTypeOpenAPISchema(op.Request)
+		}
+		if op.Response != "" {
+			response = goTypeOpenAPISchema(op.Response)
+		}
+	}
+
+	if out.Summary == "" {
+		out.Summary = summary
+	}
+	if request != nil {
+		out.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content:  map[string]*openAPIMediaType{"application/json": {Schema: request}},
+		}
+	}
+	success := &openAPIResponse{Description: http.StatusText(op.Status)}
+	if response != nil && op.Status != http.StatusNoContent {
+		success.Content = map[string]*openAPIMediaType{"application/json": {Schema: response}}
+	}
+	if op.Name == "GetAll" && op.ListArray {
+		success.Headers = listArrayHeaders()
+	}
+	out.Responses = append(out.Responses, yamlEntry[*openAPIResponse]{strconv.Itoa(op.Status), success})
+	for _, status := range errorStatuses(data, op, request != nil) {
+		out.Responses = append(out.Responses, yamlEntry[*openAPIResponse]{strconv.Itoa(status), &openAPIResponse{
+			Description: http.StatusText(status),
+			Content:     map[string]*openAPIMediaType{"application/problem+json": {Schema: &openAPISchema{Ref: "#/components/schemas/Problem"}}},
+		}})
+	}
+	return out
+}
+
+// listArrayHeaders mengembalikan header pagination yang dikirim list
+// endpoint berbentuk array tanpa envelope, lihat query.Headers
+func listArrayHeaders() map[string]*openAPIHeader {
+	return map[string]*openAPIHeader{
+		"X-Total-Count": {
+			Description: "Jumlah seluruh item yang cocok dengan filter",
+			Schema:      &openAPISchema{Type: schemaType{Name: "integer"}},
+		},
+		"Link": {
+			Description: "URL halaman berikutnya dengan rel=\"next\"; tidak dikirim pada halaman terakhir",
+			Schema:      &openAPISchema{Type: schemaType{Name: "string"}},
+		},
+	}
+}
+
+// errorStatuses mengembalikan status error yang dapat dikirim handler
+// untuk operasi, sesuai pemetaan jenis apperror pada problem.go
+func errorStatuses(data moduleData, op Operation, hasBody bool) []int {
+	var statuses []int
+	if len(op.Params) > 0 || hasBody {
+		statuses = append(statuses, http.StatusBadRequest)
+	}
+	switch op.Name {
+	case "GetByID", "Update", "Delete":
+		statuses = append(statuses, http.StatusNotFound)
+	}
+	if op.Name == "Create" || op.Name == "Update" {
+		for _, f := range data.Fields {
+			if f.Unique {
+				statuses = append(statuses, http.StatusConflict)
+				break
+			}
+		}
+		statuses = append(statuses, http.StatusUnprocessableEntity)
+	}
+	statuses = append(statuses, http.StatusInternalServerError)
+	// Stub operasi non-CRUD mengembalikan apperror.NotImplemented
+	if !op.IsCRUD() {
+		statuses = append(statuses, http.StatusNotImplemented)
+	}
+	return statuses
+}
+
+// listOpenAPIParameters mendeskripsikan parameter pagination, sort, dan
+// filter GET list sesuai whitelist pada handler
+func listOpenAPIParameters(data moduleData) []*openAPIParameter {
+	integer := func() *openAPISchema { return &openAPISchema{Type: schemaType{Name: "integer"}} }
+	str := func() *openAPISchema { return &openAPISchema{Type: schemaType{Name: "string"}} }
+	params := []*openAPIParameter{
+		{Name: "page", In: "query", Description: "Halaman pada pagination offset, mulai dari 1", Schema: integer()},
+		{Name: "page_size", In: "query", Description: "Jumlah item per halaman", Schema: integer()},
+		{Name: "cursor", In: "query", Description: "Cursor dari meta.next_cursor; kosong untuk halaman pertama pagination cursor", Schema: str()},
+	}
+
+	var sortable []string
+	for _, f := range data.ListFields() {
+		sortable = append(sortable, f.Name)
+	}
+	params = append(params, &openAPIParameter{
+		Name: "sort", In: "query", Schema: str(),
+		Description: "Urutan dipisah koma, awali dengan - untuk descending. Field: " + strings.Join(sortable, ", "),
+	})
+
+	for _, f := range data.ListFields() {
+		for _, op := range f.Ops {
+			p := &openAPIParameter{Name: f.Name + "[" + op + "]", In: "query", Schema: listFieldOpenAPISchema(f)}
+			switch op {
+			case "eq":
+				p.Name = f.Name
+			case "like":
+				p.Schema = str()
+			case "in":
+				p.Schema, p.Description = str(), "Nilai dipisah koma"
+			}
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// listFieldOpenAPISchema memetakan tipe nilai filter ke schema OpenAPI
+func listFieldOpenAPISchema(f listField) *openAPISchema {
+	switch f.Type {
+	case "Int":
+		return &openAPISchema{Type: schemaType{Name: "integer"}}
+	case "Float":
+		return &openAPISchema{Type: schemaType{Name: "number"}}
+	case "Bool":
+		return &openAPISchema{Type: schemaType{Name: "boolean"}}
+	case "Time":
+		return &openAPISchema{Type: schemaType{Name: "string"}, Format: "date-time"}
+	}
+	return &openAPISchema{Type: schemaType{Name: "string"}}
+}
+
+// listOpenAPISchema mendeskripsikan envelope respons list modul
+func listOpenAPISchema(data moduleData) *openAPISchema {
+	s := &openAPISchema{Type: schemaType{Name: "object"}, Required: []string{"data", "meta"}}
+	s.Properties = yamlMap[*openAPISchema]{
+		{"data", &openAPISchema{Type: schemaType{Name: "array"}, Items: &openAPISchema{Ref: "#/components/schemas/" + data.Name + "Response"}}},
+		{"meta", &openAPISchema{Ref: "#/components/schemas/PaginationMeta"}},
+	}
+	return s
+}
+
+// paginationOpenAPISchema mendeskripsikan metadata pagination (query.Meta)
+func paginationOpenAPISchema() *openAPISchema {
+	integer := func() *openAPISchema { return &openAPISchema{Type: schemaType{Name: "integer"}} }
+	s := &openAPISchema{Type: schemaType{Name: "object"}, Required: []string{"page_size", "total"}}
+	s.Properties = yamlMap[*openAPISchema]{
+		{"page", integer()},
+		{"page_size", integer()},
+		{"total", &openAPISchema{Type: schemaType{Name: "integer"}, Format: "int64"}},
+		{"total_pages", integer()},
+		{"next_cursor", &openAPISchema{Type: schemaType{Name: "string"}}},
+	}
+	return s
+}
+
+// problemOpenAPISchema mendeskripsikan body respons error RFC 7807
+func problemOpenAPISchema() *openAPISchema {
+	str := func() *openAPISchema { return &openAPISchema{Type: schemaType{Name: "string"}} }
+	fieldError := &openAPISchema{Type: schemaType{Name: "object"}}
+	fieldError.Properties = yamlMap[*openAPISchema]{{"field", str()}, {"message", str()}}
+	s := &openAPISchema{Type: schemaType{Name: "object"}, Required: []string{"type", "title", "status"}}
+	s.Properties = yamlMap[*openAPISchema]{
+		{"type", str()},
+		{"title", str()},
+		{"status", &openAPISchema{Type: schemaType{Name: "integer"}}},
+		{"detail", str()},
+		{"instance", str()},
+		{"errors", &openAPISchema{Type: schemaType{Name: "array"}, Items: fieldError}},
+	}
+	return s
+}
+
+// requestOpenAPISchema mendeskripsikan body request create dan update
+// modul. Field writeonly boleh tidak dikirim pada request update.
+func requestOpenAPISchema(data moduleData, update bool) *openAPISchema {
+	s := &openAPISchema{Type: schemaType{Name: "object"}}
+	for _, f := range data.RequestFields() {
+		prop := fieldOpenAPISchema(f)
+		prop.WriteOnly = f.WriteOnly
+		s.Properties = append(s.Properties, yamlEntry[*openAPISchema]{f.JSONName, prop})
+		if !f.Nullable && !f.HasDefault && !(update && f.WriteOnly) {
+			s.Required = append(s.Required, f.JSONName)
+		}
+	}
+	return s
+}
+
+// responseOpenAPISchema mendeskripsikan respons modul sesuai field-nya
+func responseOpenAPISchema(data moduleData) *openAPISchema {
+	s := &openAPISchema{Type: schemaType{Name: "object"}, Required: []string{"id"}}
+	s.Properties = append(s.Properties, yamlEntry[*openAPISchema]{"id", &openAPISchema{Type: schemaType{Name: "integer"}, ReadOnly: true}})
+	for _, f := range data.ResponseFields() {
+		prop := fieldOpenAPISchema(f)
+		prop.ReadOnly = f.ReadOnly
+		s.Properties = append(s.Properties, yamlEntry[*openAPISchema]{f.JSONName, prop})
+		if !f.Nullable {
+			s.Required = append(s.Required, f.JSONName)
+		}
+	}
+	if data.Timestamps {
+		for _, name := range []string{"created_at", "updated_at"} {
+			s.Properties = append(s.Properties, yamlEntry[*openAPISchema]{name, &openAPISchema{Type: schemaType{Name: "string"}, Format: "date-time", ReadOnly: true}})
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// fieldOpenAPISchema memetakan tipe field spec ke schema OpenAPI
+func fieldOpenAPISchema(f Field) *openAPISchema {
+	s := &openAPISchema{Nullable: f.Nullable}
+	switch f.Type {
+	case "string", "text":
+		s.Type.Name = "string"
+	case "int", "uint":
+		s.Type.Name = "integer"
+	case "int64":
+		s.Type.Name, s.Format = "integer", "int64"
+	case "float":
+		s.Type.Name, s.Format = "number", "double"
+	case "decimal":
+		s.Type.Name = "number"
+	case "bool":
+		s.Type.Name = "boolean"
+	case "time":
+		s.Type.Name, s.Format = "string", "date-time"
+	case "date":
+		s.Type.Name, s.Format = "string", "date"
+	}
+	if f.HasDefault && !f.IsTime() {
+		s.Default = openAPIDefault(s.Type.Name, f.Default)
+	}
+	if f.Email {
+		s.Format = "email"
+	}
+	s.Pattern = f.Pattern
+	for _, v := range f.Enum {
+		s.Enum = append(s.Enum, openAPIDefault(s.Type.Name, v))
+	}
+	if f.isString() {
+		s.MinLength, s.MaxLength = intBound(f.Min), intBound(f.Max)
+	} else {
+		s.Minimum, s.Maximum = floatBound(f.Min), floatBound(f.Max)
+	}
+	return s
+}
+
+// intBound mengubah batas aturan validasi menjadi nilai minLength/maxLength
+func intBound(v string) *int {
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+// floatBound mengubah batas aturan validasi menjadi nilai minimum/maximum
+func floatBound(v string) *float64 {
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+// openAPIDefault mengubah nilai default atau enum field menjadi nilai
+// bertipe sesuai schema
+func openAPIDefault(typ, value string) interface{} {
+	switch typ {
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	}
+	return value
+}
+
+// dtoOpenAPISchema mendeskripsikan DTO modul
+func dtoOpenAPISchema(dto DTO) *openAPISchema {
+	s := &openAPISchema{Type: schemaType{Name: "object"}, Description: dto.Description}
+	for _, f := range dto.Fields {
+		s.Properties = append(s.Properties, yamlEntry[*openAPISchema]{f.JSONName, goTypeOpenAPISchema(f.GoType)})
+		if !f.Optional {
+			s.Required = append(s.Required, f.JSONName)
+		}
+	}
+	return s
+}
+
+// goTypeOpenAPISchema memetakan tipe Go pada DTO atau operasi ke schema
+// OpenAPI; tipe DTO menjadi $ref ke component schema, dan tipe entity ke
+// schema respons modulnya karena field JSON keduanya sama
+func goTypeOpenAPISchema(t string) *openAPISchema {
+	t = strings.TrimPrefix(t, "*")
+	switch {
+	case strings.HasPrefix(t, "[]"):
+		return &openAPISchema{Type: schemaType{Name: "array"}, Items: goTypeOpenAPISchema(t[2:])}
+	case strings.HasPrefix(t, "map["):
+		return &openAPISchema{Type: schemaType{Name: "object"}}
+	}
+	switch t {
+	case "interface{}":
+		return &openAPISchema{}
+	case "string":
+		return &openAPISchema{Type: schemaType{Name: "string"}}
+	case "int", "uint":
+		return &openAPISchema{Type: schemaType{Name: "integer"}}
+	case "int64":
+		return &openAPISchema{Type: schemaType{Name: "integer"}, Format: "int64"}
+	case "float64":
+		return &openAPISchema{Type: schemaType{Name: "number"}}
+	case "bool":
+		return &openAPISchema{Type: schemaType{Name: "boolean"}}
+	case "time.Time":
+		return &openAPISchema{Type: schemaType{Name: "string"}, Format: "date-time"}
+	}
+	name := t
+	if entity, ok := strings.CutPrefix(t, "entity."); ok {
+		name = entity + "Response"
+	} else if i := strings.LastIndex(t, "."); i >= 0 {
+		name = t[i+1:]
+	}
+	return &openAPISchema{Ref: "#/components/schemas/" + name}
+}
+
+// yamlNode meng-encode v menjadi node YAML
+func yamlNode(v interface{}) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(v); err != nil {
+		return nil, fmt.Errorf("gagal encode %s: %w", openAPISpecFile, err)
+	}
+	return &node, nil
+}
+
+// yamlChild mengembalikan nilai key pada mapping m; mapping kosong dibuat
+// jika key belum ada
+func yamlChild(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			child := m.Content[i+1]
+			if child.Kind != yaml.MappingNode {
+				*child = yaml.Node{Kind: yaml.MappingNode}
+			}
+			return child
+		}
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	yamlSet(m, key, child)
+	return child
+}
+
+// yamlSet mengganti atau menambahkan key pada mapping m. Mapping yang
+// diubah ditulis dengan gaya block, bukan {} seperti mapping kosong.
+func yamlSet(m *yaml.Node, key string, value *yaml.Node) {
+	m.Style = 0
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1] = value
+			return
+		}
+	}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// List berbentuk array tanpa envelope mengirim total dan halaman berikutnya
+// lewat header pada semua framework, dan header itu tercatat pada spesifikasi
+func TestListArrayPaginationHeaders(t *testing.T) {
+	setHeader := map[string]string{
+		"stdlib": "w.Header().Set(key, value)",
+		"chi":    "w.Header().Set(key, value)",
+		"mux":    "w.Header().Set(key, value)",
+		"gin":    "c.Header(key, value)",
+		"echo":   "c.Response().Header().Set(key, value)",
+		"fiber":  "c.Set(key, value)",
+	}
+	for _, framework := range HTTPFrameworks() {
+		t.Run(framework, func(t *testing.T) {
+			project := newProject(t, framework)
+			ops := defaultOperations("pet")
+			ops[0].ListArray = true
+			fields, err := ParseFields([]string{"name:string"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			g := NewModuleGenerator("pet")
+			g.SetProject(project)
+			g.SetFields(fields)
+			g.SetOperations(ops)
+			g.SetWriter(&Writer{Out: io.Discard})
+			g.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+			if err := g.Generate(); err != nil {
+				t.Fatalf("Generate: %v", err)
+			}
+
+			handler := readFile(t, filepath.Join(project.Root, "internal", "delivery", "http", "pet_handler.go"))
+			for _, want := range []string{"items, meta, err := h.usecase.GetAll(", "range query.Headers(", setHeader[framework]} {
+				if !strings.Contains(handler, want) {
+					t.Errorf("handler tidak memuat %q:\n%s", want, handler)
+				}
+			}
+
+			spec := readFile(t, filepath.Join(project.Root, filepath.FromSlash(openAPISpecFile)))
+			for _, want := range []string{"X-Total-Count:", "Link:"} {
+				if strings.Count(spec, want) != 1 {
+					t.Errorf("spesifikasi memuat %q %d kali, want 1:\n%s", want, strings.Count(spec, want), spec)
+				}
+			}
+		})
+	}
+}
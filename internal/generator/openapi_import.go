@@ -0,0 +1,806 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jinzhu/inflection"
+)
+
+// ReadOpenAPISchema membaca spesifikasi OpenAPI 3 lalu memetakan component
+// schema entity (object dengan properti id, atau x-capy-entity: true) ke
+// modul. Operasi pada paths dikelompokkan ke modul sesuai segmen resource-nya,
+// mis. /orders/{id}/cancel ke modul order; schema lain yang dipakai operasi
+// menjadi DTO pada internal/dto.
+func ReadOpenAPISchema(path string) ([]ModuleSchema, error) {
+	doc, err := loadOpenAPI(path)
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &openAPIImporter{
+		doc:      doc,
+		entities: make(map[string]*ModuleSchema),
+		dtoOwner: make(map[string]*ModuleSchema),
+	}
+	if err := imp.readEntities(); err != nil {
+		return nil, err
+	}
+	if len(imp.modules) == 0 {
+		return nil, fmt.Errorf("tidak ada schema entity (object dengan properti id) pada %s", path)
+	}
+	if err := imp.readOperations(); err != nil {
+		return nil, err
+	}
+	if err := imp.readDTOs(); err != nil {
+		return nil, err
+	}
+	if err := imp.mapBodies(); err != nil {
+		return nil, err
+	}
+	if err := imp.checkDTONames(); err != nil {
+		return nil, err
+	}
+
+	modules := orderByDependencies(imp.modules,
+		func(m *ModuleSchema) string { return tableName(m.Name) },
+		func(m *ModuleSchema) []string {
+			var refs []string
+			for _, f := range m.Fields {
+				refs = append(refs, f.References())
+			}
+			return refs
+		})
+	schemas := make([]ModuleSchema, 0, len(modules))
+	for _, m := range modules {
+		if len(m.Operations) == 0 {
+			m.Warnings = append(m.Warnings, fmt.Sprintf("schema %s tidak memiliki operasi pada paths, handler dibuat tanpa route", toPascal(m.Name)))
+		}
+		sortOperations(m.Operations)
+		schemas = append(schemas, *m)
+	}
+	return schemas, nil
+}
+
+type openAPIImporter struct {
+	doc      *openAPIDoc
+	modules  []*ModuleSchema
+	entities map[string]*ModuleSchema // nama schema -> modul
+	dtoOwner map[string]*ModuleSchema // nama schema DTO -> modul yang membuatnya
+	pending  []string                 // schema DTO yang belum dibentuk
+	bodies   []crudBody               // DTO yang dipakai sebagai body request CRUD
+}
+
+// crudBody adalah DTO dari spesifikasi yang dipakai sebagai body request
+// Create atau Update modul
+type crudBody struct {
+	module *ModuleSchema
+	schema string // nama schema DTO
+	create bool   // dipakai operasi Create sehingga butuh ToEntity
+}
+
+// readEntities membentuk modul dari setiap component schema entity
+func (imp *openAPIImporter) readEntities() error {
+	var names []string
+	for _, e := range imp.doc.Components.Schemas {
+		s, err := imp.doc.flatten(e.Value)
+		if err != nil {
+			return fmt.Errorf("schema %s: %w", e.Key, err)
+		}
+		if isEntitySchema(s) {
+			m := &ModuleSchema{Name: toSnake(e.Key), Operations: []Operation{}}
+			imp.entities[e.Key] = m
+			imp.modules = append(imp.modules, m)
+			names = append(names, e.Key)
+		}
+	}
+
+	for _, name := range names {
+		s, _ := imp.doc.Components.Schemas.get(name)
+		if err := imp.readFields(imp.entities[name], name, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isEntitySchema(s *openAPISchema) bool {
+	if s.Entity != nil {
+		return *s.Entity
+	}
+	_, hasID := s.Properties.get("id")
+	return hasID && (s.Type.Name == "" || s.Type.Name == "object")
+}
+
+// readFields memetakan properti schema entity ke field modul
+func (imp *openAPIImporter) readFields(m *ModuleSchema, name string, s *openAPISchema) error {
+	s, err := imp.doc.flatten(s)
+	if err != nil {
+		return fmt.Errorf("schema %s: %w", name, err)
+	}
+	if p, ok := s.Properties.get("id"); ok {
+		id, err := imp.doc.flatten(p)
+		if err != nil {
+			return fmt.Errorf("schema %s: %w", name, err)
+		}
+		if id.Type.Name != "integer" {
+			return fmt.Errorf("schema %s: properti id harus bertipe integer", name)
+		}
+	}
+
+	columns := make(map[string]bool)
+	for _, p := range s.Properties {
+		columns[toSnake(p.Key)] = true
+	}
+	timestamps := columns["created_at"] && columns["updated_at"]
+	m.Table.NoTimestamps = !timestamps
+
+	for _, p := range s.Properties {
+		column := toSnake(p.Key)
+		if column == "id" || (timestamps && (column == "created_at" || column == "updated_at")) {
+			continue
+		}
+		if isBaseColumn(column) {
+			m.Warnings = append(m.Warnings, fmt.Sprintf("properti %s.%s bentrok dengan field bawaan entity dan dilewati", name, p.Key))
+			continue
+		}
+		prop, err := imp.doc.flatten(p.Value)
+		if err != nil {
+			return fmt.Errorf("schema %s: properti %s: %w", name, p.Key, err)
+		}
+		typ := openAPIFieldType(prop)
+		if typ == "" || p.Value.Ref != "" && prop.Type.Name == "object" {
+			m.Warnings = append(m.Warnings, fmt.Sprintf("properti %s.%s bertipe %s tidak didukung dan dilewati", name, p.Key, describeSchema(p.Value, prop)))
+			continue
+		}
+
+		f := Field{
+			Name:      toPascal(p.Key),
+			Column:    column,
+			JSONName:  p.Key,
+			Type:      typ,
+			GoType:    fieldTypes[typ].GoType,
+			Nullable:  !s.required(p.Key) || prop.Nullable || prop.Type.Null,
+			ReadOnly:  prop.ReadOnly,
+			WriteOnly: prop.WriteOnly && !prop.ReadOnly,
+		}
+		switch v := prop.Default.(type) {
+		case string, bool, int, float64:
+			f.Default, f.HasDefault = fmt.Sprint(v), true
+		}
+		if ref, ok := imp.referencedEntity(column); ok && prop.Type.Name == "integer" {
+			// Foreign key mengikuti tipe kolom id entity yang dirujuk
+			f.Type, f.GoType = "uint", fieldTypes["uint"].GoType
+			f.ForeignKey = true
+			f.RefTable = tableName(ref.Name)
+		}
+		if err := f.readRules(prop); err != nil {
+			m.Warnings = append(m.Warnings, fmt.Sprintf("aturan validasi properti %s.%s dilewati: %v", name, p.Key, err))
+			f.clearRules()
+		}
+		if f.Pointer() {
+			f.GoType = "*" + f.GoType
+		}
+		m.Fields = append(m.Fields, f)
+	}
+	return nil
+}
+
+// readRules membaca batasan schema properti (minLength, maximum, pattern,
+// enum, format email, ...) sebagai aturan validasi field
+func (f *Field) readRules(s *openAPISchema) error {
+	f.Email = s.Format == "email"
+	f.Pattern = s.Pattern
+	if f.isString() {
+		if s.MinLength != nil {
+			f.Min = strconv.Itoa(*s.MinLength)
+		}
+		if s.MaxLength != nil {
+			f.Max = strconv.Itoa(*s.MaxLength)
+		}
+	} else {
+		if s.Minimum != nil {
+			f.Min = strconv.FormatFloat(*s.Minimum, 'f', -1, 64)
+		}
+		if s.Maximum != nil {
+			f.Max = strconv.FormatFloat(*s.Maximum, 'f', -1, 64)
+		}
+	}
+	for _, v := range s.Enum {
+		if v != nil {
+			f.Enum = append(f.Enum, fmt.Sprint(v))
+		}
+	}
+	if f.Pattern != "" {
+		if _, err := regexp.Compile(f.Pattern); err != nil {
+			return fmt.Errorf("pattern %q tidak didukung: %w", f.Pattern, err)
+		}
+	}
+	return f.checkRules()
+}
+
+// clearRules menghapus seluruh aturan validasi field
+func (f *Field) clearRules() {
+	f.Required, f.Email = false, false
+	f.Min, f.Max, f.Pattern = "", "", ""
+	f.Enum = nil
+}
+
+// referencedEntity mengembalikan modul yang dirujuk kolom foreign key,
+// mis. customer_id merujuk schema Customer
+func (imp *openAPIImporter) referencedEntity(column string) (*ModuleSchema, bool) {
+	if !strings.HasSuffix(column, "_id") {
+		return nil, false
+	}
+	target := strings.TrimSuffix(column, "_id")
+	for _, m := range imp.modules {
+		if m.Name == target {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+// openAPIFieldType memetakan schema properti ke tipe field spec, atau
+// string kosong jika tidak didukung
+func openAPIFieldType(s *openAPISchema) string {
+	switch s.Type.Name {
+	case "string":
+		switch s.Format {
+		case "date-time":
+			return "time"
+		case "date":
+			return "date"
+		}
+		if s.MaxLength != nil && *s.MaxLength > 255 {
+			return "text"
+		}
+		return "string"
+	case "integer":
+		if s.Format == "int64" {
+			return "int64"
+		}
+		return "int"
+	case "number":
+		if s.Format == "decimal" {
+			return "decimal"
+		}
+		return "float"
+	case "boolean":
+		return "bool"
+	}
+	return ""
+}
+
+// describeSchema menjelaskan tipe schema untuk pesan peringatan
+func describeSchema(raw, s *openAPISchema) string {
+	if raw.Ref != "" {
+		return raw.Ref
+	}
+	if s.Type.Name == "" {
+		return "tanpa tipe"
+	}
+	return s.Type.Name
+}
+
+// readOperations mengelompokkan operasi pada paths ke modul
+func (imp *openAPIImporter) readOperations() error {
+	for _, e := range imp.doc.Paths {
+		for _, mo := range e.Value.operations() {
+			if err := imp.readOperation(e.Key, e.Value, mo); err != nil {
+				return fmt.Errorf("operasi %s %s: %w", mo.Method, e.Key, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (imp *openAPIImporter) readOperation(path string, item *openAPIPathItem, mo methodOperation) error {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	m, resource := imp.resource(segments)
+	if m == nil {
+		// Operasi tanpa schema entity, mis. GET /stats, dibuat sebagai stub
+		// operasi modul pertama sehingga tetap memiliki handler dan usecase
+		m = imp.modules[0]
+		m.Warnings = append(m.Warnings, fmt.Sprintf("operasi %s %s tidak memiliki schema entity dan dibuat sebagai stub pada modul %s", mo.Method, path, m.Name))
+	}
+
+	params, err := imp.pathParams(segments, resource, item.Parameters, mo.Operation.Parameters)
+	if err != nil {
+		return err
+	}
+	status, response, err := imp.successResponse(mo.Operation)
+	if err != nil {
+		return err
+	}
+
+	op := Operation{
+		Method:  mo.Method,
+		Path:    path,
+		Status:  status,
+		Summary: firstLine(mo.Operation.Summary),
+		Params:  params,
+	}
+	if name := crudOperationName(m, segments, resource, mo.Method, params); name != "" {
+		op.Name = name
+		switch name {
+		case "GetAll":
+			op.ListArray, err = imp.listArray(m, response)
+		case "Create", "Update":
+			op.Request, err = imp.crudRequest(m, name, mo)
+		}
+		if err != nil {
+			return err
+		}
+		m.Operations = append(m.Operations, op)
+		return nil
+	}
+
+	op.Name = customOperationName(m, segments[resource+1:], mo)
+	body, err := imp.doc.requestBody(mo.Operation.RequestBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		if s := jsonSchema(body.Content); s != nil {
+			if op.Request, err = imp.goType(m, s, ""); err != nil {
+				return err
+			}
+		}
+	}
+	if response != nil && status != http.StatusNoContent {
+		t, err := imp.goType(m, response, "")
+		if err != nil {
+			return err
+		}
+		op.Response = pointerType(t)
+	}
+	m.Operations = append(m.Operations, op)
+	return nil
+}
+
+// resource mengembalikan modul dan posisi segmen resource pertama pada path,
+// mis. orders pada /api/v1/orders/{id}
+func (imp *openAPIImporter) resource(segments []string) (*ModuleSchema, int) {
+	for i, seg := range segments {
+		if isPathParam(seg) {
+			continue
+		}
+		for _, name := range []string{toSnake(inflection.Singular(seg)), toSnake(seg)} {
+			for _, m := range imp.modules {
+				if m.Name == name {
+					return m, i
+				}
+			}
+		}
+	}
+	return nil, -1
+}
+
+func isPathParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// pathParams membaca path parameter sesuai urutan pada path. Parameter
+// integer tepat setelah segmen resource dianggap ID resource (uint).
+func (imp *openAPIImporter) pathParams(segments []string, resource int, shared, own []*openAPIParameter) ([]Param, error) {
+	defs := make(map[string]*openAPIParameter)
+	for _, list := range [][]*openAPIParameter{shared, own} {
+		for _, p := range list {
+			p, err := imp.doc.parameter(p)
+			if err != nil {
+				return nil, err
+			}
+			if p.In == "path" {
+				defs[p.Name] = p
+			}
+		}
+	}
+
+	var params []Param
+	for i, seg := range segments {
+		if !isPathParam(seg) {
+			continue
+		}
+		p := Param{Name: strings.Trim(seg, "{}"), GoType: "string"}
+		if def, ok := defs[p.Name]; ok && def.Schema != nil {
+			s, err := imp.doc.flatten(def.Schema)
+			if err != nil {
+				return nil, err
+			}
+			if s.Type.Name == "integer" {
+				p.GoType = "int"
+				if i == resource+1 || strings.HasSuffix(strings.ToLower(p.Name), "id") {
+					p.GoType = "uint"
+				}
+			}
+		}
+		params = append(params, p)
+	}
+	return params, nil
+}
+
+// successResponse mengembalikan status 2xx terkecil beserta schema JSON-nya.
+// Operasi tanpa respons 2xx dianggap mengembalikan 200.
+func (imp *openAPIImporter) successResponse(op *openAPIOperation) (int, *openAPISchema, error) {
+	status, found := http.StatusOK, false
+	var response *openAPIResponse
+	for _, e := range op.Responses {
+		code, err := strconv.Atoi(strings.Replace(strings.ToUpper(e.Key), "2XX", "200", 1))
+		if err != nil || code < 200 || code > 299 || found && code >= status {
+			continue
+		}
+		status, found, response = code, true, e.Value
+	}
+	response, err := imp.doc.response(response)
+	if err != nil || response == nil {
+		return status, nil, err
+	}
+	return status, jsonSchema(response.Content), nil
+}
+
+// listArray memeriksa bentuk respons list pada spesifikasi. Array entity
+// dilayani apa adanya tanpa envelope, sedangkan object harus berupa envelope
+// dengan properti data seperti respons list bawaan capy.
+func (imp *openAPIImporter) listArray(m *ModuleSchema, response *openAPISchema) (bool, error) {
+	if response == nil {
+		return false, nil
+	}
+	s, err := imp.doc.flatten(response)
+	if err != nil {
+		return false, err
+	}
+	name := toPascal(m.Name)
+	if s.Type.Name == "array" {
+		t, err := imp.goType(m, s, "")
+		if err != nil {
+			return false, err
+		}
+		if t != "[]entity."+name {
+			return false, fmt.Errorf("respons list berupa %s, sedangkan list modul %s hanya dapat mengembalikan array %s", t, m.Name, name)
+		}
+		return true, nil
+	}
+	if _, ok := s.Properties.get("data"); !ok {
+		return false, fmt.Errorf("respons list harus berupa array %s atau envelope dengan properti data dan meta", name)
+	}
+	return false, nil
+}
+
+// crudRequest mengembalikan DTO body request operasi Create atau Update
+// jika spesifikasi mendeklarasikan schema selain entity modul, mis. NewPet.
+// String kosong berarti handler memakai request bawaan modul.
+func (imp *openAPIImporter) crudRequest(m *ModuleSchema, name string, mo methodOperation) (string, error) {
+	body, err := imp.doc.requestBody(mo.Operation.RequestBody)
+	if err != nil || body == nil {
+		return "", err
+	}
+	s := jsonSchema(body.Content)
+	if s == nil {
+		return "", nil
+	}
+	builtin := name + toPascal(m.Name) + "Request"
+	if s.Ref == "" {
+		m.Warnings = append(m.Warnings, fmt.Sprintf("body request inline pada %s diabaikan, handler memakai %s; jadikan component schema agar dipakai", mo.Method, builtin))
+		return "", nil
+	}
+	schema, err := refName(s.Ref, "schemas")
+	if err != nil {
+		return "", err
+	}
+	if entity, ok := imp.entities[schema]; ok {
+		if entity != m {
+			m.Warnings = append(m.Warnings, fmt.Sprintf("body request %s pada %s diabaikan karena merupakan entity modul lain, handler memakai %s", schema, mo.Method, builtin))
+		}
+		return "", nil
+	}
+
+	t, err := imp.goType(m, s, "")
+	if err != nil || !strings.HasPrefix(t, "dto.") {
+		return "", err
+	}
+	imp.bodies = append(imp.bodies, crudBody{module: m, schema: toPascal(schema), create: name == "Create"})
+	return t, nil
+}
+
+// mapBodies memetakan field DTO body request CRUD ke field entity modulnya
+// berdasarkan nama JSON, sehingga DTO tersebut memiliki method Apply dan
+// ToEntity. Properti yang tidak dapat dipetakan dilaporkan sebagai
+// peringatan karena tidak disimpan.
+func (imp *openAPIImporter) mapBodies() error {
+	for _, body := range imp.bodies {
+		dto := imp.findDTO(body.schema)
+		if dto == nil {
+			continue
+		}
+		m, entity := body.module, toPascal(body.module.Name)
+		dto.ToEntity = dto.ToEntity || body.create
+		if dto.Entity == entity {
+			continue
+		}
+		if dto.Entity != "" {
+			return fmt.Errorf("schema %s dipakai sebagai body request entity %s dan %s", dto.Name, dto.Entity, entity)
+		}
+		dto.Entity = entity
+
+		for i := range dto.Fields {
+			df := &dto.Fields[i]
+			var target *Field
+			for j := range m.Fields {
+				if m.Fields[j].JSONName == df.JSONName && m.Fields[j].InRequest() {
+					target = &m.Fields[j]
+				}
+			}
+			switch {
+			case target == nil:
+				m.Warnings = append(m.Warnings, fmt.Sprintf("properti %s.%s tidak ada pada entity %s yang dapat diisi request, nilainya tidak disimpan", dto.Name, df.JSONName, entity))
+				continue
+			case df.GoType == target.GoType && df.Optional && strings.HasPrefix(df.GoType, "*"):
+				df.Conversion = "optional"
+			case df.GoType == target.GoType:
+			case df.GoType == "*"+target.GoType:
+				df.Conversion = "deref"
+			case "*"+df.GoType == target.GoType:
+				df.Conversion = "addr"
+			default:
+				m.Warnings = append(m.Warnings, fmt.Sprintf("properti %s.%s bertipe %s tidak cocok dengan %s.%s bertipe %s, nilainya tidak disimpan", dto.Name, df.JSONName, df.GoType, entity, target.Name, target.GoType))
+				continue
+			}
+			df.EntityField = target.Name
+		}
+	}
+	return nil
+}
+
+// findDTO mengembalikan DTO bernama name dari modul pemiliknya
+func (imp *openAPIImporter) findDTO(name string) *DTO {
+	for _, m := range imp.modules {
+		for i := range m.DTOs {
+			if m.DTOs[i].Name == name {
+				return &m.DTOs[i]
+			}
+		}
+	}
+	return nil
+}
+
+// crudOperationName mengembalikan nama operasi CRUD jika operasi cocok dengan
+// route CRUD modul dan belum dipakai, atau string kosong. Path tanpa segmen
+// resource modul (resource -1) tidak pernah menjadi operasi CRUD.
+func crudOperationName(m *ModuleSchema, segments []string, resource int, method string, params []Param) string {
+	if resource < 0 {
+		return ""
+	}
+	for _, seg := range segments[:resource] {
+		if isPathParam(seg) {
+			return ""
+		}
+	}
+
+	name := ""
+	rest := segments[resource+1:]
+	switch {
+	case len(rest) == 0 && method == http.MethodGet:
+		name = "GetAll"
+	case len(rest) == 0 && method == http.MethodPost:
+		name = "Create"
+	case len(rest) == 1 && len(params) == 1 && params[0].GoType == "uint":
+		switch method {
+		case http.MethodGet:
+			name = "GetByID"
+		case http.MethodPut, http.MethodPatch:
+			name = "Update"
+		case http.MethodDelete:
+			name = "Delete"
+		}
+	}
+	if name == "" || hasOperation(m, name) {
+		return ""
+	}
+	return name
+}
+
+// customOperationName menamai operasi non-CRUD dari operationId, atau dari
+// segmen path setelah resource, mis. POST /orders/{id}/cancel menjadi Cancel
+// dan DELETE /orders/{id}/items/{sku} menjadi DeleteItems
+func customOperationName(m *ModuleSchema, rest []string, mo methodOperation) string {
+	name := toPascal(mo.Operation.OperationID)
+	if name == "" {
+		var words []string
+		for _, seg := range rest {
+			if !isPathParam(seg) {
+				words = append(words, seg)
+			}
+		}
+		name = toPascal(strings.Join(words, "_"))
+		switch mo.Method {
+		case http.MethodPut, http.MethodPatch, http.MethodDelete:
+			name = toPascal(strings.ToLower(mo.Method)) + name
+		}
+	}
+	verb := toPascal(strings.ToLower(mo.Method))
+	if name == "" {
+		name = verb
+	}
+
+	candidates := []string{name, verb + name}
+	for i := 2; ; i++ {
+		for _, c := range candidates {
+			if !isCRUDOperation(c) && c != "RegisterRoutes" && !hasOperation(m, c) {
+				return c
+			}
+		}
+		candidates = []string{fmt.Sprintf("%s%d", name, i)}
+	}
+}
+
+func hasOperation(m *ModuleSchema, name string) bool {
+	for _, op := range m.Operations {
+		if op.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// goType mengembalikan tipe Go untuk schema. Schema entity menjadi
+// entity.Nama, schema object lain menjadi DTO milik modul owner. pkg adalah
+// package tempat tipe dipakai; tipe DTO di dalam package dto tidak
+// di-qualify.
+func (imp *openAPIImporter) goType(owner *ModuleSchema, s *openAPISchema, pkg string) (string, error) {
+	if s == nil {
+		return "interface{}", nil
+	}
+	if s.Ref == "" && len(s.AllOf) == 1 && len(s.Properties) == 0 {
+		return imp.goType(owner, s.AllOf[0], pkg)
+	}
+	if s.Ref != "" {
+		name, err := refName(s.Ref, "schemas")
+		if err != nil {
+			return "", err
+		}
+		if m, ok := imp.entities[name]; ok {
+			return "entity." + toPascal(m.Name), nil
+		}
+		target, err := imp.doc.flatten(s)
+		if err != nil {
+			return "", err
+		}
+		if target.Type.Name == "object" || len(target.Properties) > 0 {
+			imp.useDTO(owner, name)
+			if pkg == "dto" {
+				return toPascal(name), nil
+			}
+			return "dto." + toPascal(name), nil
+		}
+		// schema primitif bernama, mis. enum status
+		return imp.goType(owner, target, pkg)
+	}
+
+	switch s.Type.Name {
+	case "array":
+		if s.Items == nil {
+			return "", errors.New("schema array tanpa items")
+		}
+		t, err := imp.goType(owner, s.Items, pkg)
+		return "[]" + t, err
+	case "object":
+		return "map[string]interface{}", nil
+	case "string":
+		if s.Format == "date-time" || s.Format == "date" {
+			return "time.Time", nil
+		}
+		return "string", nil
+	case "integer":
+		if s.Format == "int64" {
+			return "int64", nil
+		}
+		return "int", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	}
+	if len(s.Properties) > 0 || len(s.AllOf) > 0 {
+		return "map[string]interface{}", nil
+	}
+	return "interface{}", nil
+}
+
+// pointerType mengembalikan tipe pointer untuk tipe yang tidak nil-able
+func pointerType(t string) string {
+	if strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[") || t == "interface{}" {
+		return t
+	}
+	return "*" + t
+}
+
+// useDTO mencatat schema name sebagai DTO milik owner jika belum dimiliki
+// modul lain
+func (imp *openAPIImporter) useDTO(owner *ModuleSchema, name string) {
+	if _, ok := imp.dtoOwner[name]; ok {
+		return
+	}
+	imp.dtoOwner[name] = owner
+	imp.pending = append(imp.pending, name)
+}
+
+// readDTOs membentuk DTO yang dipakai operasi, termasuk DTO yang dirujuk
+// DTO lain
+func (imp *openAPIImporter) readDTOs() error {
+	for len(imp.pending) > 0 {
+		name := imp.pending[0]
+		imp.pending = imp.pending[1:]
+		owner := imp.dtoOwner[name]
+
+		s, _ := imp.doc.Components.Schemas.get(name)
+		s, err := imp.doc.flatten(s)
+		if err != nil {
+			return fmt.Errorf("schema %s: %w", name, err)
+		}
+		dto := DTO{Name: toPascal(name), Description: firstLine(s.Description)}
+		for _, p := range s.Properties {
+			t, err := imp.goType(owner, p.Value, "dto")
+			if err != nil {
+				return fmt.Errorf("schema %s: properti %s: %w", name, p.Key, err)
+			}
+			prop, err := imp.doc.flatten(p.Value)
+			if err != nil {
+				return fmt.Errorf("schema %s: properti %s: %w", name, p.Key, err)
+			}
+			optional := !s.required(p.Key) || prop.Nullable || prop.Type.Null
+			if optional {
+				t = pointerType(t)
+			}
+			dto.Fields = append(dto.Fields, DTOField{Name: toPascal(p.Key), JSONName: p.Key, GoType: t, Optional: optional})
+		}
+		owner.DTOs = append(owner.DTOs, dto)
+	}
+	return nil
+}
+
+// checkDTONames memastikan DTO dari spesifikasi tidak bernama sama dengan
+// DTO request/respons yang dibuat capy untuk setiap entity
+func (imp *openAPIImporter) checkDTONames() error {
+	reserved := make(map[string]string)
+	for _, m := range imp.modules {
+		for _, name := range moduleDTONames(toPascal(m.Name)) {
+			reserved[name] = m.Name
+		}
+	}
+	for _, m := range imp.modules {
+		for _, dto := range m.DTOs {
+			if owner, ok := reserved[dto.Name]; ok {
+				return fmt.Errorf("schema %s bentrok dengan DTO bawaan modul %s, ganti nama schema tersebut", dto.Name, owner)
+			}
+		}
+	}
+	return nil
+}
+
+// sortOperations mengurutkan operasi agar route dengan segmen literal
+// didaftarkan sebelum route dengan parameter pada posisi yang sama, mis.
+// /orders/search sebelum /orders/{id}
+func sortOperations(ops []Operation) {
+	sort.SliceStable(ops, func(i, j int) bool {
+		a := strings.Split(strings.Trim(ops[i].Path, "/"), "/")
+		b := strings.Split(strings.Trim(ops[j].Path, "/"), "/")
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if pa, pb := isPathParam(a[k]), isPathParam(b[k]); pa != pb {
+				return pb
+			}
+		}
+		return len(a) < len(b)
+	})
+}
+
+// firstLine mengembalikan baris pertama teks untuk dipakai pada komentar
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,192 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// petSchemas adalah components spesifikasi uji dengan entity Pet
+const petSchemas = `
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id: {type: integer}
+        name: {type: string}
+        tag: {type: string}
+    NewPet:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+        tag: {type: string}
+`
+
+// readOpenAPI menulis spesifikasi paths beserta petSchemas lalu membacanya
+func readOpenAPI(t *testing.T, paths string) ([]ModuleSchema, error) {
+	t.Helper()
+	spec := "openapi: 3.0.3\ninfo: {title: Pets, version: 1.0.0}\npaths:\n" + paths + petSchemas
+	path := filepath.Join(t.TempDir(), "api.yaml")
+	if err := os.WriteFile(path, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return ReadOpenAPISchema(path)
+}
+
+// listPaths membuat GET /pets dengan schema respons schema
+func listPaths(schema string) string {
+	return `
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: ` + schema + "\n"
+}
+
+func TestReadOpenAPIListResponse(t *testing.T) {
+	tests := []struct {
+		name      string
+		schema    string
+		listArray bool
+		wantErr   string
+	}{
+		{name: "array entity", schema: `{type: array, items: {$ref: '#/components/schemas/Pet'}}`, listArray: true},
+		{name: "envelope", schema: `{type: object, properties: {data: {type: array, items: {$ref: '#/components/schemas/Pet'}}, meta: {type: object}}}`},
+		{name: "array DTO", schema: `{type: array, items: {$ref: '#/components/schemas/NewPet'}}`, wantErr: "respons list berupa []dto.NewPet"},
+		{name: "object tanpa data", schema: `{type: object, properties: {items: {type: array, items: {$ref: '#/components/schemas/Pet'}}}}`, wantErr: "envelope dengan properti data"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modules, err := readOpenAPI(t, listPaths(tt.schema))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			ops := modules[0].Operations
+			if len(ops) != 1 || ops[0].Name != "GetAll" {
+				t.Fatalf("operations = %+v, want GetAll", ops)
+			}
+			if ops[0].ListArray != tt.listArray {
+				t.Errorf("ListArray = %v, want %v", ops[0].ListArray, tt.listArray)
+			}
+		})
+	}
+}
+
+func TestReadOpenAPICRUDRequestBody(t *testing.T) {
+	paths := `
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/NewPet'}
+      responses:
+        "201": {description: created}
+  /pets/{id}:
+    put:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      requestBody:
+        content:
+          application/json:
+            schema: {type: object, properties: {name: {type: string}}}
+      responses:
+        "200": {description: ok}
+`
+	modules, err := readOpenAPI(t, paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := modules[0]
+
+	requests := make(map[string]string)
+	for _, op := range m.Operations {
+		requests[op.Name] = op.Request
+	}
+	if requests["Create"] != "dto.NewPet" || requests["Update"] != "" {
+		t.Errorf("request = %v, want Create dto.NewPet dan Update bawaan", requests)
+	}
+
+	if len(m.DTOs) != 1 {
+		t.Fatalf("DTOs = %+v, want NewPet", m.DTOs)
+	}
+	dto := m.DTOs[0]
+	if dto.Entity != "Pet" || !dto.ToEntity {
+		t.Errorf("NewPet Entity=%q ToEntity=%v, want Pet dan true", dto.Entity, dto.ToEntity)
+	}
+	mapped := make(map[string]string)
+	for _, f := range dto.Fields {
+		mapped[f.JSONName] = f.EntityField + "/" + f.Conversion
+	}
+	if want := map[string]string{"name": "Name/", "tag": "Tag/optional"}; !reflect.DeepEqual(mapped, want) {
+		t.Errorf("pemetakan NewPet = %v, want %v", mapped, want)
+	}
+
+	if len(m.Warnings) != 1 || !strings.Contains(m.Warnings[0], "body request inline pada PUT diabaikan") {
+		t.Errorf("warnings = %q, want peringatan body inline", m.Warnings)
+	}
+}
+
+// Operasi pada path tanpa schema entity dibuat sebagai stub pada modul
+// pertama, bukan dilewati
+func TestReadOpenAPIPathWithoutEntity(t *testing.T) {
+	modules, err := readOpenAPI(t, listPaths(`{type: array, items: {$ref: '#/components/schemas/Pet'}}`)+`
+  /stats:
+    get:
+      summary: Statistik toko
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {type: object, properties: {total: {type: integer}}}
+  /reports/{year}:
+    post:
+      operationId: generateReport
+      parameters:
+        - {name: year, in: path, required: true, schema: {type: integer}}
+      responses:
+        "204": {description: dibuat}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pet := modules[0]
+	var got []string
+	for _, op := range pet.Operations {
+		got = append(got, op.Method+" "+op.Path+" "+op.Name+" "+op.Response)
+	}
+	want := []string{
+		"GET /pets GetAll ",
+		"GET /stats Stats map[string]interface{}",
+		"POST /reports/{year} GenerateReport ",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("operasi = %q, want %q", got, want)
+	}
+	if p := pet.Operations[2].Params; len(p) != 1 || p[0].Name != "year" || p[0].GoType != "int" {
+		t.Errorf("params GenerateReport = %+v, want year int", p)
+	}
+	wantWarnings := []string{
+		"operasi GET /stats tidak memiliki schema entity dan dibuat sebagai stub pada modul pet",
+		"operasi POST /reports/{year} tidak memiliki schema entity dan dibuat sebagai stub pada modul pet",
+	}
+	if !reflect.DeepEqual(pet.Warnings, wantWarnings) {
+		t.Errorf("warnings = %q, want %q", pet.Warnings, wantWarnings)
+	}
+}
@@ -0,0 +1,172 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"net/http"
+	"strings"
+)
+
+// crudOperations adalah operasi bawaan handler modul. Method handler dan
+// usecase-nya selalu bernama sama sehingga tidak dapat dipakai operasi lain.
+var crudOperations = []string{"GetAll", "GetByID", "Create", "Update", "Delete"}
+
+// Operation adalah satu operasi HTTP yang dilayani handler modul
+type Operation struct {
+	Name     string  // nama method handler dan usecase, mis. GetAll atau Cancel
+	Method   string  // method HTTP, mis. GET
+	Path     string  // path route mux, mis. /orders/{id}
+	Status   int     // status code respons sukses
+	Summary  string  // ringkasan operasi dari spesifikasi
+	Params   []Param // path parameter sesuai urutan pada path
+	Request  string  // tipe Go body request; pada Create dan Update berisi DTO pengganti request bawaan, kosong jika tidak ada
+	Response string  // tipe Go body respons operasi non-CRUD, kosong jika tanpa body
+	// ListArray menandakan GetAll mengembalikan array respons tanpa envelope
+	// {data, meta}, sesuai spesifikasi OpenAPI yang diimpor
+	ListArray bool
+}
+
+// Param adalah path parameter operasi
+type Param struct {
+	Name   string // nama parameter pada path, mis. orderId
+	GoType string // uint, int, atau string
+}
+
+// defaultOperations adalah lima route REST bawaan modul resource
+func defaultOperations(resource string) []Operation {
+	collection := "/" + resource + "s"
+	item := collection + "/{id}"
+	id := []Param{{Name: "id", GoType: "uint"}}
+	return []Operation{
+		{Name: "GetAll", Method: http.MethodGet, Path: collection, Status: http.StatusOK},
+		{Name: "GetByID", Method: http.MethodGet, Path: item, Status: http.StatusOK, Params: id},
+		{Name: "Create", Method: http.MethodPost, Path: collection, Status: http.StatusCreated},
+		{Name: "Update", Method: http.MethodPut, Path: item, Status: http.StatusOK, Params: id},
+		{Name: "Delete", Method: http.MethodDelete, Path: item, Status: http.StatusNoContent, Params: id},
+	}
+}
+
+// IsCRUD menandakan operasi bawaan yang diteruskan ke repository
+func (o Operation) IsCRUD() bool {
+	return isCRUDOperation(o.Name)
+}
+
+func isCRUDOperation(name string) bool {
+	for _, crud := range crudOperations {
+		if name == crud {
+			return true
+		}
+	}
+	return false
+}
+
+// IDParam mengembalikan nama path parameter ID pada operasi CRUD
+func (o Operation) IDParam() string {
+	if len(o.Params) > 0 {
+		return o.Params[0].Name
+	}
+	return "id"
+}
+
+// Signature mengembalikan signature method usecase operasi non-CRUD, mis.
+// Cancel(ctx context.Context, id uint, req dto.CancelOrderRequest) (*entity.Order, error)
+func (o Operation) Signature() string {
+	params := []string{"ctx context.Context"}
+	for _, p := range o.Params {
+		params = append(params, p.Var()+" "+p.GoType)
+	}
+	if o.Request != "" {
+		params = append(params, "req "+o.Request)
+	}
+	results := "error"
+	if o.Response != "" {
+		results = "(" + o.Response + ", error)"
+	}
+	return fmt.Sprintf("%s(%s) %s", o.Name, strings.Join(params, ", "), results)
+}
+
+// Args mengembalikan argumen pemanggilan usecase dari handler; ctx adalah
+// ekspresi context request pada framework HTTP, mis. r.Context()
+func (o Operation) Args(ctx string) string {
+	args := []string{ctx}
+	for _, p := range o.Params {
+		args = append(args, p.Arg())
+	}
+	if o.Request != "" {
+		args = append(args, "req")
+	}
+	return strings.Join(args, ", ")
+}
+
+// handlerVars adalah nama variabel yang sudah dipakai pada method handler
+var handlerVars = map[string]bool{
+	"w": true, "r": true, "h": true, "vars": true, "err": true, "req": true, "result": true, "ctx": true, "c": true,
+}
+
+// Var mengembalikan nama variabel Go untuk parameter
+func (p Param) Var() string {
+	name := toCamel(p.Name)
+	if name == "" || token.IsKeyword(name) || handlerVars[name] {
+		name += "Param"
+	}
+	return name
+}
+
+// Arg mengembalikan ekspresi argumen parameter setelah di-parse handler
+func (p Param) Arg() string {
+	if p.GoType == "uint" {
+		return "uint(" + p.Var() + ")"
+	}
+	return p.Var()
+}
+
+// DTO adalah tipe data request atau respons yang tidak disimpan ke database
+type DTO struct {
+	Name        string
+	Description string
+	Fields      []DTOField
+	// Entity adalah entity yang diisi method Apply, untuk DTO yang dipakai
+	// sebagai body request Create atau Update. Kosong berarti tanpa Apply.
+	Entity   string
+	ToEntity bool // buat method ToEntity untuk body request Create
+}
+
+// DTOField adalah satu field DTO
+type DTOField struct {
+	Name        string // nama field Go
+	JSONName    string
+	GoType      string
+	Optional    bool
+	EntityField string // field entity yang diisi Apply, kosong jika tidak dipetakan
+	// Conversion adalah cara Apply mengisi field entity: "deref" untuk
+	// pointer ke nilai dan "optional" untuk pointer ke pointer (keduanya
+	// hanya jika dikirim), "addr" untuk nilai ke pointer, kosong jika langsung
+	Conversion string
+}
+
+// Tag menghasilkan struct tag json untuk field DTO
+func (f DTOField) Tag() string {
+	if f.Optional {
+		return fmt.Sprintf("json:%q", f.JSONName+",omitempty")
+	}
+	return fmt.Sprintf("json:%q", f.JSONName)
+}
+
+// httpStatusConst mengembalikan nama konstanta net/http untuk status code,
+// mis. 201 menjadi http.StatusCreated
+func httpStatusConst(code int) string {
+	if name, ok := httpStatusNames[code]; ok {
+		return "http." + name
+	}
+	return fmt.Sprint(code)
+}
+
+var httpStatusNames = map[int]string{
+	http.StatusOK:                   "StatusOK",
+	http.StatusCreated:              "StatusCreated",
+	http.StatusAccepted:             "StatusAccepted",
+	http.StatusNonAuthoritativeInfo: "StatusNonAuthoritativeInfo",
+	http.StatusNoContent:            "StatusNoContent",
+	http.StatusResetContent:         "StatusResetContent",
+	http.StatusPartialContent:       "StatusPartialContent",
+}
@@ -2,36 +2,82 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
-	"strings"
+	"sort"
 )
 
 // ProjectGenerator bertanggung jawab untuk membuat struktur proyek baru
 type ProjectGenerator struct {
 	projectName  string
 	basePath     string
+	modulePath   string
 	databaseType string
+	httpType     string
+	docs         bool // sajikan Swagger UI pada /docs
+	database     *databaseProfile
+	http         *httpProfile
+	writer       *Writer
 }
 
 func (g *ProjectGenerator) SetDatabaseType(dbType string) {
 	g.databaseType = dbType
 }
 
+// SetModulePath mengatur path modul Go (baris module pada go.mod) yang
+// terpisah dari nama direktori proyek, mis. github.com/acme/billing
+func (g *ProjectGenerator) SetModulePath(modulePath string) {
+	g.modulePath = modulePath
+}
+
+// SetHTTP mengatur framework HTTP proyek, mis. gin. Kosong berarti
+// DefaultHTTP.
+func (g *ProjectGenerator) SetHTTP(framework string) {
+	g.httpType = framework
+}
+
+// SetDocs mengatur apakah proyek menyajikan Swagger UI untuk
+// api/openapi.yaml pada route /docs
+func (g *ProjectGenerator) SetDocs(docs bool) {
+	g.docs = docs
+}
+
 // NewProjectGenerator membuat instance baru ProjectGenerator
 func NewProjectGenerator(projectName string) *ProjectGenerator {
 	return &ProjectGenerator{
 		projectName: projectName,
 		basePath:    projectName,
+		modulePath:  projectName,
+		writer:      NewWriter(),
 	}
 }
 
+// SetWriter mengatur Writer yang dipakai Generate, mis. untuk mode dry-run
+func (g *ProjectGenerator) SetWriter(w *Writer) {
+	g.writer = w
+}
+
 // Generate membuat struktur folder dan file dasar untuk proyek baru
 func (g *ProjectGenerator) Generate() error {
-	// Create base directory
-	if err := os.MkdirAll(g.basePath, 0755); err != nil {
-		return fmt.Errorf("gagal membuat direktori proyek: %w", err)
+	set := NewFileSet()
+	if err := g.Render(set); err != nil {
+		return err
+	}
+	return g.writer.Write(set)
+}
+
+// Render me-render struktur folder dan file dasar proyek ke dalam set
+// tanpa menulis ke disk
+func (g *ProjectGenerator) Render(set *FileSet) error {
+	database, err := lookupDatabaseProfile(g.databaseType)
+	if err != nil {
+		return err
+	}
+	g.database = database
+	profile, err := lookupHTTPProfile(g.httpType)
+	if err != nil {
+		return err
 	}
+	g.http = profile
 
 	// Create all required directories
 	dirs := []string{
@@ -42,501 +88,190 @@ func (g *ProjectGenerator) Generate() error {
 		"internal/entity",
 		"pkg/database",
 		"pkg/middleware",
+		"migrations",
+		"api",
+	}
+	if g.docs {
+		dirs = append(dirs, "pkg/docs")
 	}
 
 	for _, dir := range dirs {
-		path := filepath.Join(g.basePath, dir)
-		if err := os.MkdirAll(path, 0755); err != nil {
-			return fmt.Errorf("gagal membuat direktori %s: %w", dir, err)
-		}
+		set.AddDir(filepath.Join(g.basePath, dir))
 	}
 
 	// Generate go.mod
-	if err := g.generateGoMod(); err != nil {
+	if err := g.generateGoMod(set); err != nil {
 		return fmt.Errorf("gagal generate go.mod: %w", err)
 	}
 
+	// Catat pilihan proyek untuk perintah capy berikutnya
+	if err := g.generateConfig(set); err != nil {
+		return fmt.Errorf("gagal generate %s: %w", configFile, err)
+	}
+
 	// Generate main.go
-	if err := g.generateMainFile(); err != nil {
+	if err := g.generateMainFile(set); err != nil {
 		return fmt.Errorf("gagal generate main.go: %w", err)
 	}
 
+	// Generate Swagger UI
+	if g.docs {
+		if err := g.generateDocs(set); err != nil {
+			return fmt.Errorf("gagal generate docs: %w", err)
+		}
+	}
+
 	// Generate database.go
-	if err := g.generateDatabaseFile(); err != nil {
+	if err := g.generateDatabaseFile(set); err != nil {
 		return fmt.Errorf("gagal generate database.go: %w", err)
 	}
 
 	// Generate .env and .env.example
-	if err := g.generateEnvFiles(); err != nil {
+	if err := g.generateEnvFiles(set); err != nil {
 		return fmt.Errorf("gagal generate env files: %w", err)
 	}
 
 	// Generate Makefile
-	if err := g.generateMakefile(); err != nil {
+	if err := g.generateMakefile(set); err != nil {
 		return fmt.Errorf("gagal generate Makefile: %w", err)
 	}
 
 	// Generate .gitignore
-	if err := g.generateGitignore(); err != nil {
+	if err := g.generateGitignore(set); err != nil {
 		return fmt.Errorf("gagal generate .gitignore: %w", err)
 	}
 
 	// Generate README.md
-	if err := g.generateReadme(); err != nil {
+	if err := g.generateReadme(set); err != nil {
 		return fmt.Errorf("gagal generate README.md: %w", err)
 	}
 
 	// Generate Dockerfile
-	if err := g.generateDockerfile(); err != nil {
+	if err := g.generateDockerfile(set); err != nil {
 		return fmt.Errorf("gagal generate Dockerfile: %w", err)
 	}
 
-	return nil
-}
-
-func (g *ProjectGenerator) generateMainFile() error {
-	mainContent := `package main
-
-import (
-	"fmt"
-	"log"
-	"net/http"
-	"os"
-
-	"github.com/gorilla/mux"
-	"github.com/joho/godotenv"
-	"PROJECT_NAME/pkg/database"
-)
-
-func main() {
-	// Load environment variables
-	if err := godotenv.Load(); err != nil {
-		log.Printf("Warning: .env file not found")
+	// Generate docker-compose.yml
+	if err := g.generateCompose(set); err != nil {
+		return fmt.Errorf("gagal generate docker-compose.yml: %w", err)
 	}
 
-	// Setup database connection
-	db, err := database.Connect()
-	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
-	}
-
-	// Run auto migrations
-	if err := database.AutoMigrate(db); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
-	}
-
-	// Setup router
-	r := mux.NewRouter()
-	
-	// Setup middleware
-	r.Use(loggingMiddleware)
-
-	// Get port from env or use default
-	port := os.Getenv("APP_PORT")
-	if port == "" {
-		port = "8080"
-	}
-
-	// Start server
-	log.Printf("Server starting on :%s", port)
-	log.Fatal(http.ListenAndServe(":"+port, r))
+	return nil
 }
 
-func loggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("%s %s", r.Method, r.RequestURI)
-		next.ServeHTTP(w, r)
-	})
-}`
-
-	mainContent = strings.Replace(mainContent, "PROJECT_NAME", g.projectName, -1)
-	return os.WriteFile(filepath.Join(g.basePath, "cmd", "main.go"), []byte(mainContent), 0644)
+// projectData adalah data yang tersedia di template proyek. DB adalah
+// profile database yang menjadi satu-satunya sumber nilai terkait database.
+type projectData struct {
+	ProjectName  string
+	ModulePath   string
+	DatabaseType string
+	DB           *databaseProfile
+	HTTP         *httpProfile
+	DBEnv        []envVar
+	Requires     []module
+	Docs         bool
 }
 
-func (g *ProjectGenerator) generateDatabaseFile() error {
-	var dbContent string
-
-	if g.databaseType == "mysql" {
-		dbContent = `package database
-
-import (
-	"fmt"
-	"os"
-
-	"gorm.io/driver/mysql"
-	"gorm.io/gorm"
-)
-
-// Config menyimpan konfigurasi database
-type Config struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	DBName   string
-	SSLMode  string
+// module adalah satu dependensi pada blok require go.mod
+type module struct {
+	Path    string
+	Version string
 }
 
-// NewConfig membuat instance Config dari environment variables
-func NewConfig() *Config {
-	return &Config{
-		Host:     os.Getenv("DB_HOST"),
-		Port:     os.Getenv("DB_PORT"),
-		User:     os.Getenv("DB_USER"),
-		Password: os.Getenv("DB_PASSWORD"),
-		DBName:   os.Getenv("DB_NAME"),
-		SSLMode:  os.Getenv("DB_SSL_MODE"),
-	}
+// baseRequires adalah dependensi proyek yang tidak bergantung pada database
+// maupun framework HTTP
+var baseRequires = []module{
+	{"github.com/joho/godotenv", "v1.5.1"},
+	{"gorm.io/gorm", "v1.25.7"},
 }
 
-// Connect membuat koneksi ke database
-func Connect() (*gorm.DB, error) {
-	config := NewConfig()
-
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		config.User, config.Password, config.Host, config.Port, config.DBName)
+// docsRequires adalah dependensi tambahan untuk Swagger UI
+var docsRequires = []module{
+	{"github.com/swaggo/files/v2", "v2.0.2"},
+}
 
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+// requires menggabungkan dependensi dasar dengan driver database dan
+// framework HTTP, berurutan seperti hasil go mod tidy
+func (g *ProjectGenerator) requires() []module {
+	mods := append([]module{{g.database.DriverModule, g.database.DriverVersion}}, baseRequires...)
+	mods = append(mods, g.http.Requires...)
+	if g.docs {
+		mods = append(mods, docsRequires...)
 	}
+	sort.Slice(mods, func(i, j int) bool { return mods[i].Path < mods[j].Path })
+	return mods
+}
 
-	// Setup connection pool
-	sqlDB, err := db.DB()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get database instance: %w", err)
+func (g *ProjectGenerator) templateData() projectData {
+	return projectData{
+		ProjectName:  g.projectName,
+		ModulePath:   g.modulePath,
+		DatabaseType: g.database.Name,
+		DB:           g.database,
+		HTTP:         g.http,
+		DBEnv:        g.database.envFor(g.projectName),
+		Requires:     g.requires(),
+		Docs:         g.docs,
 	}
-
-	// Set connection pool settings
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-
-	return db, nil
 }
 
-// AutoMigrate menjalankan migrasi database untuk semua model
-func AutoMigrate(db *gorm.DB) error {
-	// Daftar model untuk auto-migrate akan ditambahkan saat generate modul
-	return nil
-}`
-	} else {
-		// Logika untuk database lain (misalnya PostgreSQL)
-		dbContent = `package database
-
-import (
-	"fmt"
-	"os"
-
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
-)
-
-// Config menyimpan konfigurasi database
-type Config struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	DBName   string
-	SSLMode  string
+func (g *ProjectGenerator) generateConfig(set *FileSet) error {
+	return g.generateFile(set, filepath.FromSlash(configFile), "project/config.yaml.tmpl")
 }
 
-// NewConfig membuat instance Config dari environment variables
-func NewConfig() *Config {
-	return &Config{
-		Host:     os.Getenv("DB_HOST"),
-		Port:     os.Getenv("DB_PORT"),
-		User:     os.Getenv("DB_USER"),
-		Password: os.Getenv("DB_PASSWORD"),
-		DBName:   os.Getenv("DB_NAME"),
-		SSLMode:  os.Getenv("DB_SSL_MODE"),
-	}
+func (g *ProjectGenerator) generateMainFile(set *FileSet) error {
+	return g.generateFile(set, filepath.Join("cmd", "main.go"), "project/main.go.tmpl")
 }
 
-// Connect membuat koneksi ke database
-func Connect() (*gorm.DB, error) {
-	config := NewConfig()
-
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		config.Host,
-		config.Port,
-		config.User,
-		config.Password,
-		config.DBName,
-		config.SSLMode,
-	)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
-	}
-
-	// Setup connection pool
-	sqlDB, err := db.DB()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get database instance: %w", err)
+func (g *ProjectGenerator) generateDocs(set *FileSet) error {
+	if err := g.generateFile(set, filepath.Join("api", "api.go"), "project/docs/api.go.tmpl"); err != nil {
+		return err
 	}
-
-	// Set connection pool settings
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-
-	return db, nil
+	return g.generateFile(set, filepath.Join("pkg", "docs", "docs.go"), "project/docs/docs.go.tmpl")
 }
 
-// AutoMigrate menjalankan migrasi database untuk semua model
-func AutoMigrate(db *gorm.DB) error {
-	// Daftar model untuk auto-migrate akan ditambahkan saat generate modul
-	return nil
-}`
-	}
-
-	return os.WriteFile(filepath.Join(g.basePath, "pkg", "database", "db.go"), []byte(dbContent), 0644)
+func (g *ProjectGenerator) generateDatabaseFile(set *FileSet) error {
+	return g.generateFile(set, filepath.Join("pkg", "database", "db.go"), g.database.Template)
 }
 
-func (g *ProjectGenerator) generateEnvFiles() error {
-	envContent := fmt.Sprintf(`# Application
-APP_NAME=%s
-APP_ENV=development
-APP_PORT=8080
-
-# Database
-DB_HOST=localhost
-DB_PORT=5432
-DB_NAME=%s
-DB_USER=postgres
-DB_PASSWORD=postgres
-DB_SSL_MODE=disable
-
-# JWT
-JWT_SECRET=your-secret-key
-JWT_EXPIRATION=24h
-
-# Redis
-REDIS_HOST=localhost
-REDIS_PORT=6379
-REDIS_PASSWORD=
-REDIS_DB=0`, g.projectName, g.projectName)
-
-	if err := os.WriteFile(filepath.Join(g.basePath, ".env"), []byte(envContent), 0644); err != nil {
+func (g *ProjectGenerator) generateEnvFiles(set *FileSet) error {
+	if err := g.generateFile(set, ".env", "project/env.tmpl"); err != nil {
 		return err
 	}
-
-	return os.WriteFile(filepath.Join(g.basePath, ".env.example"), []byte(envContent), 0644)
+	return g.generateFile(set, ".env.example", "project/env.tmpl")
 }
 
-func (g *ProjectGenerator) generateMakefile() error {
-	makefileContent := fmt.Sprintf(`.PHONY: build run test clean deps lint mock dev migrate-create migrate-up migrate-down
-
-# Build the application
-build:
-	go build -o bin/%s cmd/main.go
-
-# Run the application
-run:
-	go run cmd/main.go
-
-# Run tests
-test:
-	go test -v ./...
-
-# Clean build artifacts
-clean:
-	rm -rf bin/
-
-# Install dependencies
-deps:
-	go mod download
-	go mod tidy
-
-# Run linter
-lint:
-	go vet ./...
-	golangci-lint run
-
-# Generate mock files
-mock:
-	mockgen -source=internal/repository/repository.go -destination=internal/mocks/repository_mock.go
-	mockgen -source=internal/usecase/usecase.go -destination=internal/mocks/usecase_mock.go
-
-# Build and run in development mode
-dev: build
-	./bin/%s
-
-# Create database migrations
-migrate-create:
-	migrate create -ext sql -dir migrations -seq $(name)
-
-# Run database migrations
-migrate-up:
-	migrate -path migrations -database "$(DB_URL)" up
-
-# Rollback database migrations
-migrate-down:
-	migrate -path migrations -database "$(DB_URL)" down`, g.projectName, g.projectName)
-
-	return os.WriteFile(filepath.Join(g.basePath, "Makefile"), []byte(makefileContent), 0644)
+func (g *ProjectGenerator) generateMakefile(set *FileSet) error {
+	return g.generateFile(set, "Makefile", "project/Makefile.tmpl")
 }
 
-func (g *ProjectGenerator) generateGitignore() error {
-	gitignoreContent := `# Binaries for programs and plugins
-*.exe
-*.exe~
-*.dll
-*.so
-*.dylib
-bin/
-
-# Test binary, built with 'go test -c'
-*.test
-
-# Output of the go coverage tool, specifically when used with LiteIDE
-*.out
-
-# Dependency directories (remove the comment below to include it)
-vendor/
-
-# Go workspace file
-go.work
-
-# IDE specific files
-.idea/
-.vscode/
-*.swp
-*.swo
-
-# Environment variables
-.env
-.env.local
-
-# Logs
-*.log
-
-# OS specific
-.DS_Store
-Thumbs.db`
-
-	return os.WriteFile(filepath.Join(g.basePath, ".gitignore"), []byte(gitignoreContent), 0644)
+func (g *ProjectGenerator) generateGitignore(set *FileSet) error {
+	return g.generateFile(set, ".gitignore", "project/gitignore.tmpl")
 }
 
-func (g *ProjectGenerator) generateReadme() error {
-	readmeContent := fmt.Sprintf(`# %s
-
-Proyek ini dibuat menggunakan [Capy](https://github.com/arraniry/capy) - Generator proyek Go dengan Clean Architecture.
-
-## Struktur Proyek
-
-`+"```"+`
-.
-├── cmd/                    # Entry points aplikasi
-├── internal/               # Private application code
-│   ├── delivery/          # Layer interface (HTTP handlers)
-│   ├── repository/        # Layer data persistence
-│   ├── usecase/           # Layer business logic
-│   └── entity/            # Enterprise business rules
-└── pkg/                   # Public libraries
-    ├── database/          # Database utilities
-    └── middleware/        # HTTP middleware
-`+"```"+`
-
-## Cara Menjalankan
-
-1. Install dependencies:
-`+"```"+`bash
-go mod download
-`+"```"+`
-
-2. Setup environment variables:
-`+"```"+`bash
-cp .env.example .env
-# Edit .env sesuai kebutuhan
-`+"```"+`
-
-3. Jalankan aplikasi:
-`+"```"+`bash
-make run
-# atau
-go run cmd/main.go
-`+"```"+`
-
-## Development
-
-`+"```"+`bash
-# Install dependencies
-make deps
-
-# Run tests
-make test
-
-# Run linter
-make lint
-
-# Build binary
-make build
-`+"```"+`
-
-## API Endpoints
-
-### Users
-- `+"`GET /users`"+` - Get all users
-- `+"`GET /users/{id}`"+` - Get user by ID
-- `+"`POST /users`"+` - Create new user
-- `+"`PUT /users/{id}`"+` - Update user
-- `+"`DELETE /users/{id}`"+` - Delete user`, g.projectName)
-
-	return os.WriteFile(filepath.Join(g.basePath, "README.md"), []byte(readmeContent), 0644)
+func (g *ProjectGenerator) generateReadme(set *FileSet) error {
+	return g.generateFile(set, "README.md", "project/README.md.tmpl")
 }
 
-func (g *ProjectGenerator) generateGoMod() error {
-	content := fmt.Sprintf(`module %s
-
-go 1.21
-
-require (
-	github.com/gorilla/mux v1.8.1
-	github.com/joho/godotenv v1.5.1
-	gorm.io/driver/postgres v1.5.6
-	gorm.io/gorm v1.25.7
-)
-`, g.projectName)
-	return os.WriteFile(filepath.Join(g.basePath, "go.mod"), []byte(content), 0644)
+func (g *ProjectGenerator) generateGoMod(set *FileSet) error {
+	return g.generateFile(set, "go.mod", "project/go.mod.tmpl")
 }
 
-func (g *ProjectGenerator) generateDockerfile() error {
-	dockerfileContent := `# Gunakan image Go resmi sebagai base image
-FROM golang:1.21 AS builder
-
-# Set working directory
-WORKDIR /app
-
-# Salin go.mod dan go.sum
-COPY go.mod go.sum ./
-
-# Download dependencies
-RUN go mod download
-
-# Salin semua file ke dalam container
-COPY . .
-
-# Build aplikasi
-RUN go build -o main ./cmd/main.go
-
-# Gunakan image yang lebih kecil untuk menjalankan aplikasi
-FROM alpine:latest
-
-# Set working directory
-WORKDIR /root/
-
-# Salin binary dari builder
-COPY --from=builder /app/main .
+func (g *ProjectGenerator) generateDockerfile(set *FileSet) error {
+	return g.generateFile(set, "Dockerfile", "project/Dockerfile.tmpl")
+}
 
-# Expose port yang digunakan aplikasi
-EXPOSE 8080
+func (g *ProjectGenerator) generateCompose(set *FileSet) error {
+	return g.generateFile(set, "docker-compose.yml", "project/docker-compose.yml.tmpl")
+}
 
-# Jalankan aplikasi
-CMD ["./main"]
-`
+func (g *ProjectGenerator) generateFile(set *FileSet, name, tmpl string) error {
+	path := filepath.Join(g.basePath, name)
+	content, err := NewRenderer(g.basePath, g.modulePath).RenderFile(tmpl, path, g.templateData())
+	if err != nil {
+		return err
+	}
 
-	return os.WriteFile(filepath.Join(g.basePath, "Dockerfile"), []byte(dockerfileContent), 0644)
+	set.Add(path, content)
+	return nil
 }
@@ -0,0 +1,593 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// registrar menambahkan modul baru ke file proyek yang sudah ada
+// (AutoMigrate dan main.go). Lokasi penyisipan dicari melalui AST dan node
+// baru dicetak dengan go/printer, lalu disisipkan ke source asli sehingga
+// suntingan dan komentar user pada file tersebut tetap utuh.
+type registrar struct {
+	set        *FileSet
+	rootDir    string
+	modulePath string
+	name       string // nama tipe modul, mis. Product
+	varPrefix  string // prefix nama variabel, mis. product
+	http       *httpProfile
+	delivery   deliveries
+	// skipAutoMigrate untuk tabel yang sudah ada, agar AutoMigrate tidak
+	// mengubah skema database lama
+	skipAutoMigrate bool
+}
+
+// textEdit mengganti src[start:end] dengan text
+type textEdit struct {
+	start, end int
+	text       string
+}
+
+// register menambahkan model ke AutoMigrate dan wiring handler ke main.go
+func (r *registrar) register() error {
+	if !r.skipAutoMigrate {
+		if err := r.edit(filepath.Join("pkg", "database", "db.go"), r.addAutoMigrate); err != nil {
+			return err
+		}
+	}
+	return r.edit(filepath.Join("cmd", "main.go"), r.addRoutes)
+}
+
+// edit mem-parse file, meminta fn menghitung suntingan berdasarkan AST-nya,
+// lalu menambahkan hasilnya ke FileSet. File yang tidak ada dilewati.
+func (r *registrar) edit(name string, fn func(*token.FileSet, *ast.File) ([]textEdit, error)) error {
+	path := filepath.Join(r.rootDir, name)
+	src, ok, err := currentContent(r.set, path)
+	if err != nil || !ok {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("gagal parse %s: %w", displayPath(path), err)
+	}
+
+	edits, err := fn(fset, file)
+	if err != nil {
+		return fmt.Errorf("gagal mendaftarkan modul di %s: %w", displayPath(path), err)
+	}
+	if len(edits) == 0 {
+		return nil
+	}
+
+	content, err := formatGo(applyEdits(src, edits), r.modulePath)
+	if err != nil {
+		return fmt.Errorf("gagal merapikan %s: %w", displayPath(path), err)
+	}
+
+	r.set.Update(path, content)
+	return nil
+}
+
+// addAutoMigrate menambahkan &entity.Name{} ke pemanggilan db.AutoMigrate
+// di dalam fungsi AutoMigrate
+func (r *registrar) addAutoMigrate(fset *token.FileSet, file *ast.File) ([]textEdit, error) {
+	fn := findFunc(file, "AutoMigrate")
+	if fn == nil || fn.Type.Params == nil || len(fn.Type.Params.List) == 0 || len(fn.Type.Params.List[0].Names) == 0 {
+		return nil, errors.New("fungsi AutoMigrate(db *gorm.DB) tidak ditemukan")
+	}
+	dbName := fn.Type.Params.List[0].Names[0].Name
+	entityPkg, edits := r.ensureImport(fset, file, "", r.modulePath+"/internal/entity")
+	model := &ast.UnaryExpr{
+		Op: token.AND,
+		X:  &ast.CompositeLit{Type: &ast.SelectorExpr{X: ast.NewIdent(entityPkg), Sel: ast.NewIdent(r.name)}},
+	}
+
+	// Tambahkan ke pemanggilan db.AutoMigrate yang sudah ada
+	var call *ast.CallExpr
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if c, ok := n.(*ast.CallExpr); ok && call == nil && isMethodCall(c, dbName, "AutoMigrate") {
+			call = c
+		}
+		return call == nil
+	})
+	if call != nil {
+		for _, arg := range call.Args {
+			if isModelLiteral(arg, entityPkg, r.name) {
+				return nil, nil
+			}
+		}
+		if len(call.Args) == 0 {
+			offset := fset.Position(call.Rparen).Offset
+			return append(edits, textEdit{offset, offset, nodeString(model)}), nil
+		}
+		offset := fset.Position(call.Args[len(call.Args)-1].End()).Offset
+		return append(edits, textEdit{offset, offset, ", " + nodeString(model)}), nil
+	}
+
+	// Belum ada pemanggilan: ganti "return nil" terakhir dengan db.AutoMigrate(...)
+	stmts := fn.Body.List
+	if len(stmts) == 0 {
+		return nil, errors.New("fungsi AutoMigrate tidak diakhiri dengan return nil")
+	}
+	ret, ok := stmts[len(stmts)-1].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 || !isIdent(ret.Results[0], "nil") {
+		return nil, errors.New("fungsi AutoMigrate tidak diakhiri dengan return nil")
+	}
+	migrate := callExpr(dbName, "AutoMigrate", model)
+	return append(edits, textEdit{
+		start: fset.Position(ret.Results[0].Pos()).Offset,
+		end:   fset.Position(ret.Results[0].End()).Offset,
+		text:  nodeString(migrate),
+	}), nil
+}
+
+// addRoutes menambahkan pembuatan repository, usecase, dan handler modul
+// beserta pemanggilan RegisterRoutes di fungsi main. Untuk delivery gRPC,
+// server modul didaftarkan ke server gRPC yang dibuat jika belum ada.
+func (r *registrar) addRoutes(fset *token.FileSet, file *ast.File) ([]textEdit, error) {
+	fn := findFunc(file, "main")
+	if fn == nil {
+		return nil, errors.New("fungsi main tidak ditemukan")
+	}
+
+	constructor := "New" + r.name + "Handler"
+	needHTTP := r.delivery.HTTP && !hasSelector(fn.Body, constructor)
+	needGRPC := r.delivery.GRPC && !hasSelector(fn.Body, "New"+r.name+"Server")
+	needGraphQL := r.delivery.GraphQL && !hasSelector(fn.Body, "New"+r.name+"Resolver")
+	if !needHTTP && !needGRPC && !needGraphQL {
+		return nil, nil
+	}
+
+	dbVar := assignedFrom(fn.Body, "database", "Connect")
+	if dbVar == "" {
+		return nil, errors.New("pemanggilan database.Connect() tidak ditemukan di main")
+	}
+	routerPkg, routerFunc := r.http.RouterPkg, r.http.RouterFunc
+	routerVar := assignedFrom(fn.Body, routerPkg, routerFunc)
+	if routerVar == "" {
+		return nil, fmt.Errorf("pemanggilan %s.%s() tidak ditemukan di main", routerPkg, routerFunc)
+	}
+
+	var edits []textEdit
+	grpcPkg, grpcVar := "", ""
+	if needGRPC {
+		var e []textEdit
+		grpcPkg, e = r.ensureImport(fset, file, "", "google.golang.org/grpc")
+		edits = append(edits, e...)
+		grpcVar = assignedFrom(fn.Body, grpcPkg, "NewServer")
+	}
+	graphqlPkg, resolverVar := "", ""
+	if needGraphQL {
+		var e []textEdit
+		graphqlPkg, e = r.ensureImport(fset, file, "deliverygraphql", r.modulePath+"/internal/delivery/graphql")
+		edits = append(edits, e...)
+		resolverVar = literalFrom(fn.Body, graphqlPkg, "Resolver")
+	}
+
+	// Sisipkan setelah router, middleware, atau modul lain yang sudah terdaftar
+	var anchor ast.Stmt
+	for _, stmt := range fn.Body.List {
+		if assignsFrom(stmt, routerPkg, routerFunc) || isRouterCall(stmt, routerVar) ||
+			grpcVar != "" && (assignsFrom(stmt, grpcPkg, "NewServer") || isServerCall(stmt, grpcVar)) ||
+			resolverVar != "" && isResolverStmt(stmt, resolverVar) {
+			anchor = stmt
+		}
+	}
+	offset := fset.Position(anchor.End()).Offset
+
+	var deliveryGRPCPkg string
+	if needGRPC {
+		var e []textEdit
+		deliveryGRPCPkg, e = r.ensureImport(fset, file, "deliverygrpc", r.modulePath+"/internal/delivery/grpc")
+		edits = append(edits, e...)
+		if grpcVar == "" {
+			grpcVar = "grpcServer"
+			e, err := r.addGRPCServer(fset, file, fn, offset, grpcPkg, grpcVar, deliveryGRPCPkg)
+			if err != nil {
+				return nil, err
+			}
+			edits = append(edits, e...)
+		}
+	}
+
+	if needGraphQL && resolverVar == "" {
+		resolverVar = "graphqlResolver"
+		e, err := r.addGraphQLSetup(fset, file, offset, graphqlPkg, resolverVar, routerVar)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, e...)
+	}
+
+	// Modul yang sudah terdaftar dengan delivery lain memakai usecase-nya
+	usecaseVar := r.varPrefix + "Usecase"
+	var stmts []ast.Stmt
+	comment := "Setup modul " + r.varPrefix
+	if isDefined(fn.Body, usecaseVar) {
+		comment = "Setup delivery modul " + r.varPrefix
+	} else {
+		repositoryPkg, e := r.ensureImport(fset, file, "", r.modulePath+"/internal/repository")
+		edits = append(edits, e...)
+		usecasePkg, e := r.ensureImport(fset, file, "", r.modulePath+"/internal/usecase")
+		edits = append(edits, e...)
+		repoVar := r.varPrefix + "Repository"
+		stmts = append(stmts,
+			defineStmt(repoVar, callExpr(repositoryPkg, "New"+r.name+"Repository", ast.NewIdent(dbVar))),
+			defineStmt(usecaseVar, callExpr(usecasePkg, "New"+r.name+"Usecase", ast.NewIdent(repoVar))))
+	}
+	if needHTTP {
+		handlerPkg, e := r.ensureImport(fset, file, "deliveryhttp", r.modulePath+"/internal/delivery/http")
+		edits = append(edits, e...)
+		handlerVar := r.varPrefix + "Handler"
+		stmts = append(stmts,
+			defineStmt(handlerVar, callExpr(handlerPkg, constructor, ast.NewIdent(usecaseVar))),
+			&ast.ExprStmt{X: callExpr(handlerVar, "RegisterRoutes", ast.NewIdent(routerVar))})
+	}
+	if needGRPC {
+		pbPkg, e := r.ensureImport(fset, file, "", r.modulePath+"/internal/delivery/grpc/pb")
+		edits = append(edits, e...)
+		server := callExpr(deliveryGRPCPkg, "New"+r.name+"Server", ast.NewIdent(usecaseVar))
+		stmts = append(stmts, &ast.ExprStmt{X: callExpr(pbPkg, "Register"+r.name+"ServiceServer", ast.NewIdent(grpcVar), server)})
+	}
+
+	if needGraphQL {
+		resolver := callExpr(graphqlPkg, "New"+r.name+"Resolver", ast.NewIdent(usecaseVar))
+		stmts = append(stmts, &ast.AssignStmt{
+			Lhs: []ast.Expr{&ast.SelectorExpr{X: ast.NewIdent(resolverVar), Sel: ast.NewIdent(r.name + "Resolver")}},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{resolver},
+		})
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n\n\t// %s", comment)
+	for _, stmt := range stmts {
+		b.WriteString("\n\t" + nodeString(stmt))
+	}
+	return append(edits, textEdit{offset, offset, b.String()}), nil
+}
+
+// addGraphQLSetup menambahkan root resolver GraphQL beserta route /graphql
+// dan /playground setelah router. Resolver modul diisi setelahnya karena
+// skema hanya memeriksa tipe resolver.
+func (r *registrar) addGraphQLSetup(fset *token.FileSet, file *ast.File, offset int, graphqlPkg, resolverVar, routerVar string) ([]textEdit, error) {
+	logPkg, edits := r.ensureImport(fset, file, "", "log")
+	setup := fmt.Sprintf(`
+
+	// Setup GraphQL pada /graphql dan playground pada /playground
+	%[1]s := &%[2]s.Resolver{}
+	if err := %[2]s.Register(%[3]s, %[1]s); err != nil {
+		%[4]s.Fatalf("Failed to setup GraphQL: %%v", err)
+	}`, resolverVar, graphqlPkg, routerVar, logPkg)
+	return append(edits, textEdit{offset, offset, setup}), nil
+}
+
+// addGRPCServer menambahkan pembuatan server gRPC setelah router,
+// menjalankannya bersama server HTTP, dan fungsi serveGRPC yang membuka
+// listener pada GRPC_PORT
+func (r *registrar) addGRPCServer(fset *token.FileSet, file *ast.File, fn *ast.FuncDecl, offset int, grpcPkg, grpcVar, deliveryPkg string) ([]textEdit, error) {
+	// Server HTTP dijalankan oleh statement terakhir main, mis.
+	// log.Fatal(http.ListenAndServe(...)); server gRPC dijalankan tepat
+	// sebelum komentar atau log yang mendahuluinya
+	stmts := fn.Body.List
+	if len(stmts) == 0 {
+		return nil, errors.New("pemanggilan server HTTP tidak ditemukan di akhir fungsi main")
+	}
+	start := stmts[len(stmts)-1]
+	if len(stmts) > 1 {
+		if expr, ok := stmts[len(stmts)-2].(*ast.ExprStmt); ok {
+			if call, ok := expr.X.(*ast.CallExpr); ok && isMethodCall(call, "log", "Printf") {
+				start = expr
+			}
+		}
+	}
+	pos := start.Pos()
+	for _, group := range file.Comments {
+		if group.Pos() > fn.Body.Lbrace && group.End() < pos &&
+			fset.Position(pos).Line-fset.Position(group.End()).Line == 1 {
+			pos = group.Pos()
+		}
+	}
+
+	reflectionPkg, edits := r.ensureImport(fset, file, "", "google.golang.org/grpc/reflection")
+	netPkg, e := r.ensureImport(fset, file, "", "net")
+	edits = append(edits, e...)
+	osPkg, e := r.ensureImport(fset, file, "", "os")
+	edits = append(edits, e...)
+	logPkg, e := r.ensureImport(fset, file, "", "log")
+	edits = append(edits, e...)
+
+	setup := fmt.Sprintf("\n\n\t// Setup server gRPC\n\t%s := %s.NewServer(%[2]s.ChainUnaryInterceptor(%s.ErrorInterceptor))\n\t%s.Register(%[1]s)",
+		grpcVar, grpcPkg, deliveryPkg, reflectionPkg)
+	run := fmt.Sprintf("// Start server gRPC\n\tgo serveGRPC(%s)\n\n\t", grpcVar)
+	serve := fmt.Sprintf(`
+
+// serveGRPC menjalankan server gRPC pada GRPC_PORT
+func serveGRPC(s *%[1]s.Server) {
+	port := %[2]s.Getenv("GRPC_PORT")
+	if port == "" {
+		port = "9090"
+	}
+
+	lis, err := %[3]s.Listen("tcp", ":"+port)
+	if err != nil {
+		%[4]s.Fatalf("Failed to listen on :%%s: %%v", port, err)
+	}
+
+	%[4]s.Printf("gRPC server starting on :%%s", port)
+	%[4]s.Fatal(s.Serve(lis))
+}
+`, grpcPkg, osPkg, netPkg, logPkg)
+
+	runOffset := fset.Position(pos).Offset
+	end := fset.File(file.Pos()).Size()
+	return append(edits,
+		textEdit{offset, offset, setup},
+		textEdit{runOffset, runOffset, run},
+		textEdit{end, end, serve}), nil
+}
+
+// ensureImport mengembalikan nama package untuk path beserta suntingan yang
+// diperlukan untuk menambahkan import tersebut jika belum ada
+func (r *registrar) ensureImport(fset *token.FileSet, file *ast.File, name, path string) (string, []textEdit) {
+	for _, spec := range file.Imports {
+		if p, _ := strconv.Unquote(spec.Path.Value); p == path {
+			if spec.Name != nil {
+				return spec.Name.Name, nil
+			}
+			return assumedPackageName(path), nil
+		}
+	}
+
+	spec := &ast.ImportSpec{Path: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(path)}}
+	pkg := assumedPackageName(path)
+	if name != "" {
+		spec.Name = ast.NewIdent(name)
+		pkg = name
+	}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		if gen.Rparen.IsValid() {
+			offset := fset.Position(gen.Rparen).Offset
+			return pkg, []textEdit{{offset, offset, "\t" + nodeString(spec) + "\n"}}
+		}
+		offset := fset.Position(gen.End()).Offset
+		return pkg, []textEdit{{offset, offset, "\nimport " + nodeString(spec)}}
+	}
+
+	offset := fset.Position(file.Name.End()).Offset
+	return pkg, []textEdit{{offset, offset, "\n\nimport " + nodeString(spec)}}
+}
+
+// applyEdits menerapkan suntingan pada src; suntingan pada offset yang sama
+// diterapkan sesuai urutan
+func applyEdits(src []byte, edits []textEdit) []byte {
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	var out bytes.Buffer
+	last := 0
+	for _, e := range edits {
+		out.Write(src[last:e.start])
+		out.WriteString(e.text)
+		last = e.end
+	}
+	out.Write(src[last:])
+	return out.Bytes()
+}
+
+// nodeString mencetak node AST menggunakan go/printer
+func nodeString(node ast.Node) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, token.NewFileSet(), node); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// currentContent membaca isi file terbaru: dari FileSet jika file tersebut
+// juga sedang di-generate, atau dari disk
+func currentContent(set *FileSet, path string) ([]byte, bool, error) {
+	if f, ok := set.Get(path); ok {
+		return f.Content, true, nil
+	}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("gagal membaca %s: %w", path, err)
+	}
+	return content, true, nil
+}
+
+func findFunc(file *ast.File, name string) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == name {
+			return fn
+		}
+	}
+	return nil
+}
+
+// assignedFrom mencari variabel yang diisi dari pemanggilan pkg.fn()
+func assignedFrom(body *ast.BlockStmt, pkg, fn string) string {
+	for _, stmt := range body.List {
+		if assign, ok := stmt.(*ast.AssignStmt); ok && assignsFrom(stmt, pkg, fn) {
+			if ident, ok := assign.Lhs[0].(*ast.Ident); ok {
+				return ident.Name
+			}
+		}
+	}
+	return ""
+}
+
+func assignsFrom(stmt ast.Stmt, pkg, fn string) bool {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || len(assign.Rhs) != 1 {
+		return false
+	}
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	return ok && isMethodCall(call, pkg, fn)
+}
+
+// hasSelector menandakan body memakai selector .name, mis. pemanggilan
+// deliveryhttp.NewProductHandler
+func hasSelector(body *ast.BlockStmt, name string) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok && sel.Sel.Name == name {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// isDefined menandakan variabel name dideklarasikan langsung di body
+func isDefined(body *ast.BlockStmt, name string) bool {
+	for _, stmt := range body.List {
+		if assign, ok := stmt.(*ast.AssignStmt); ok && assign.Tok == token.DEFINE {
+			for _, lhs := range assign.Lhs {
+				if isIdent(lhs, name) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isRouterCall menandakan statement berupa pemanggilan method pada router,
+// mis. r.Use(...) atau productHandler.RegisterRoutes(r)
+func isRouterCall(stmt ast.Stmt, routerVar string) bool {
+	expr, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	call, ok := expr.X.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	if isIdent(sel.X, routerVar) {
+		return true
+	}
+	return sel.Sel.Name == "RegisterRoutes" && len(call.Args) == 1 && isIdent(call.Args[0], routerVar)
+}
+
+// literalFrom mencari variabel yang diisi dari &pkg.typ{}
+func literalFrom(body *ast.BlockStmt, pkg, typ string) string {
+	for _, stmt := range body.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			continue
+		}
+		ident, ok := assign.Lhs[0].(*ast.Ident)
+		if ok && isModelLiteral(assign.Rhs[0], pkg, typ) {
+			return ident.Name
+		}
+	}
+	return ""
+}
+
+// isResolverStmt menandakan statement yang membuat, mendaftarkan, atau
+// mengisi root resolver GraphQL, mis. graphqlResolver.ProductResolver = ...
+func isResolverStmt(stmt ast.Stmt, resolverVar string) bool {
+	switch s := stmt.(type) {
+	case *ast.AssignStmt:
+		switch lhs := s.Lhs[0].(type) {
+		case *ast.Ident:
+			return lhs.Name == resolverVar
+		case *ast.SelectorExpr:
+			return isIdent(lhs.X, resolverVar)
+		}
+	case *ast.IfStmt:
+		if init, ok := s.Init.(*ast.AssignStmt); ok && len(init.Rhs) == 1 {
+			if call, ok := init.Rhs[0].(*ast.CallExpr); ok {
+				for _, arg := range call.Args {
+					if isIdent(arg, resolverVar) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isServerCall menandakan statement berupa pemanggilan pada server gRPC,
+// mis. reflection.Register(grpcServer) atau
+// pb.RegisterProductServiceServer(grpcServer, ...)
+func isServerCall(stmt ast.Stmt, serverVar string) bool {
+	expr, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	call, ok := expr.X.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && isIdent(sel.X, serverVar) {
+		return true
+	}
+	return len(call.Args) > 0 && isIdent(call.Args[0], serverVar)
+}
+
+func isMethodCall(call *ast.CallExpr, recv, method string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == method && isIdent(sel.X, recv)
+}
+
+func isModelLiteral(expr ast.Expr, pkg, name string) bool {
+	unary, ok := expr.(*ast.UnaryExpr)
+	if !ok || unary.Op != token.AND {
+		return false
+	}
+	lit, ok := unary.X.(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+	sel, ok := lit.Type.(*ast.SelectorExpr)
+	return ok && isIdent(sel.X, pkg) && sel.Sel.Name == name
+}
+
+func isIdent(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+func callExpr(recv, fn string, args ...ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{
+		Fun:  &ast.SelectorExpr{X: ast.NewIdent(recv), Sel: ast.NewIdent(fn)},
+		Args: args,
+	}
+}
+
+// defineStmt membuat statement "name := value"
+func defineStmt(name string, value ast.Expr) *ast.AssignStmt {
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{ast.NewIdent(name)},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{value},
+	}
+}
@@ -0,0 +1,251 @@
+package generator
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRegistrarAddAutoMigrate(t *testing.T) {
+	const header = "package database\n\nimport (\n\t\"gorm.io/gorm\"\n)\n\n"
+	tests := []struct {
+		name    string
+		src     string
+		want    string // kosong berarti file tidak diubah
+		wantErr string
+	}{
+		{
+			name: "ganti return nil",
+			src:  header + "// AutoMigrate menjalankan migrasi\nfunc AutoMigrate(db *gorm.DB) error {\n\t// komentar user\n\treturn nil\n}\n",
+			want: "package database\n\nimport (\n\t\"gorm.io/gorm\"\n\n\t\"example.com/shop/internal/entity\"\n)\n\n" +
+				"// AutoMigrate menjalankan migrasi\nfunc AutoMigrate(db *gorm.DB) error {\n\t// komentar user\n\treturn db.AutoMigrate(&entity.Product{})\n}\n",
+		},
+		{
+			name: "tambah ke pemanggilan yang ada",
+			src: "package database\n\nimport (\n\t\"gorm.io/gorm\"\n\n\t\"example.com/shop/internal/entity\"\n)\n\n" +
+				"func AutoMigrate(conn *gorm.DB) error {\n\treturn conn.AutoMigrate(\n\t\t&entity.User{},\n\t)\n}\n",
+			want: "package database\n\nimport (\n\t\"gorm.io/gorm\"\n\n\t\"example.com/shop/internal/entity\"\n)\n\n" +
+				"func AutoMigrate(conn *gorm.DB) error {\n\treturn conn.AutoMigrate(\n\t\t&entity.User{}, &entity.Product{},\n\t)\n}\n",
+		},
+		{
+			name: "pemanggilan kosong",
+			src:  header + "func AutoMigrate(db *gorm.DB) error {\n\treturn db.AutoMigrate()\n}\n",
+			want: "package database\n\nimport (\n\t\"gorm.io/gorm\"\n\n\t\"example.com/shop/internal/entity\"\n)\n\n" +
+				"func AutoMigrate(db *gorm.DB) error {\n\treturn db.AutoMigrate(&entity.Product{})\n}\n",
+		},
+		{
+			name: "alias import entity",
+			src: "package database\n\nimport (\n\t\"gorm.io/gorm\"\n\n\tmodel \"example.com/shop/internal/entity\"\n)\n\n" +
+				"func AutoMigrate(db *gorm.DB) error {\n\treturn db.AutoMigrate(&model.User{})\n}\n",
+			want: "package database\n\nimport (\n\t\"gorm.io/gorm\"\n\n\tmodel \"example.com/shop/internal/entity\"\n)\n\n" +
+				"func AutoMigrate(db *gorm.DB) error {\n\treturn db.AutoMigrate(&model.User{}, &model.Product{})\n}\n",
+		},
+		{
+			name: "sudah terdaftar",
+			src: "package database\n\nimport (\n\t\"gorm.io/gorm\"\n\n\t\"example.com/shop/internal/entity\"\n)\n\n" +
+				"func AutoMigrate(db *gorm.DB) error {\n\treturn db.AutoMigrate(&entity.Product{})\n}\n",
+		},
+		{
+			name:    "fungsi tidak ada",
+			src:     header + "func Migrate(db *gorm.DB) error {\n\treturn nil\n}\n",
+			wantErr: "fungsi AutoMigrate(db *gorm.DB) tidak ditemukan",
+		},
+		{
+			name:    "tidak diakhiri return nil",
+			src:     header + "func AutoMigrate(db *gorm.DB) error {\n\treturn db.Error\n}\n",
+			wantErr: "tidak diakhiri dengan return nil",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			name := filepath.Join("pkg", "database", "db.go")
+			writeFiles(t, root, map[string]string{name: tt.src})
+
+			set := NewFileSet()
+			r := &registrar{set: set, rootDir: root, modulePath: "example.com/shop", name: "Product"}
+			err := r.edit(name, r.addAutoMigrate)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, ok := set.Get(filepath.Join(root, name))
+			switch {
+			case tt.want == "" && ok:
+				t.Errorf("file diubah walaupun model sudah terdaftar:\n%s", f.Content)
+			case tt.want != "" && !ok:
+				t.Errorf("file tidak diubah")
+			case ok && string(f.Content) != tt.want:
+				t.Errorf("db.go =\n%s\nwant\n%s", f.Content, tt.want)
+			}
+		})
+	}
+}
+
+// newProject membuat proyek sqlite baru dengan framework HTTP framework dan
+// mengembalikan root proyek
+func newProject(t *testing.T, framework string) *Project {
+	t.Helper()
+	root := filepath.Join(t.TempDir(), "shop")
+	g := NewProjectGenerator(root)
+	g.SetModulePath("example.com/shop")
+	g.SetDatabaseType("sqlite")
+	g.SetHTTP(framework)
+	g.SetWriter(&Writer{Out: io.Discard})
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate proyek: %v", err)
+	}
+	return &Project{Root: root, ModulePath: "example.com/shop", Database: "sqlite", HTTP: framework}
+}
+
+// generateDelivery menjalankan ModuleGenerator dengan delivery pada project
+func generateDelivery(t *testing.T, project *Project, name string, delivery []string, specs ...string) {
+	t.Helper()
+	fields, err := ParseFields(specs)
+	if err != nil {
+		t.Fatalf("ParseFields: %v", err)
+	}
+	g := NewModuleGenerator(name)
+	g.SetProject(project)
+	g.SetFields(fields)
+	g.SetDelivery(delivery)
+	g.SetWriter(&Writer{Conflict: ConflictOverwrite, Out: io.Discard})
+	g.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+}
+
+// Modul didaftarkan sekali pada AutoMigrate dan main.go walaupun generate
+// dijalankan ulang
+func TestRegisterModule(t *testing.T) {
+	project := newProject(t, "")
+	generateDelivery(t, project, "product", nil, "name:string")
+	generateDelivery(t, project, "category", nil, "name:string")
+	generateDelivery(t, project, "product", nil, "name:string", "price:decimal")
+
+	db := readFile(t, filepath.Join(project.Root, "pkg", "database", "db.go"))
+	if !strings.Contains(db, "return db.AutoMigrate(&entity.Product{}, &entity.Category{})") {
+		t.Errorf("db.go tidak mendaftarkan Product dan Category sekali:\n%s", db)
+	}
+
+	main := readFile(t, filepath.Join(project.Root, "cmd", "main.go"))
+	for _, want := range []string{
+		"productRepository := repository.NewProductRepository(db)",
+		"productHandler := deliveryhttp.NewProductHandler(productUsecase)",
+		"productHandler.RegisterRoutes(r)",
+		"categoryHandler.RegisterRoutes(r)",
+	} {
+		if n := strings.Count(main, want); n != 1 {
+			t.Errorf("main.go memuat %q %d kali, want 1:\n%s", want, n, main)
+		}
+	}
+	if strings.Index(main, "productHandler.RegisterRoutes") > strings.Index(main, "categoryHandler.RegisterRoutes") {
+		t.Errorf("modul category tidak disisipkan setelah product:\n%s", main)
+	}
+}
+
+// Delivery gRPC yang ditambahkan pada modul yang sudah ada memakai usecase
+// modul tersebut, dan server gRPC hanya dibuat sekali untuk semua modul
+func TestRegisterGRPCDelivery(t *testing.T) {
+	for _, framework := range []string{"mux", "gin"} {
+		t.Run(framework, func(t *testing.T) {
+			project := newProject(t, framework)
+			generateDelivery(t, project, "product", nil, "name:string")
+			generateDelivery(t, project, "product", []string{"http", "grpc"}, "name:string")
+			generateDelivery(t, project, "category", []string{"grpc"}, "name:string")
+			generateDelivery(t, project, "product", []string{"http", "grpc"}, "name:string")
+
+			for _, name := range []string{
+				filepath.Join("api", "proto", "product.proto"),
+				filepath.Join("internal", "delivery", "grpc", "product_server.go"),
+				filepath.Join("internal", "delivery", "grpc", "pb", "product_grpc.pb.go"),
+				filepath.Join("internal", "delivery", "grpc", "category_server.go"),
+			} {
+				readFile(t, filepath.Join(project.Root, name))
+			}
+
+			main := readFile(t, filepath.Join(project.Root, "cmd", "main.go"))
+			for _, want := range []string{
+				"productRepository := repository.NewProductRepository(db)",
+				"productUsecase := usecase.NewProductUsecase(productRepository)",
+				"productHandler.RegisterRoutes(",
+				"grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(deliverygrpc.ErrorInterceptor))",
+				"reflection.Register(grpcServer)",
+				"pb.RegisterProductServiceServer(grpcServer, deliverygrpc.NewProductServer(productUsecase))",
+				"categoryUsecase := usecase.NewCategoryUsecase(categoryRepository)",
+				"pb.RegisterCategoryServiceServer(grpcServer, deliverygrpc.NewCategoryServer(categoryUsecase))",
+				"go serveGRPC(grpcServer)",
+				"func serveGRPC(s *grpc.Server) {",
+			} {
+				if n := strings.Count(main, want); n != 1 {
+					t.Errorf("main.go memuat %q %d kali, want 1:\n%s", want, n, main)
+				}
+			}
+			if strings.Contains(main, "categoryHandler") {
+				t.Errorf("modul category tanpa delivery http mendapat handler:\n%s", main)
+			}
+			if strings.Index(main, "productUsecase :=") > strings.Index(main, "pb.RegisterProductServiceServer") {
+				t.Errorf("server gRPC product didaftarkan sebelum usecase dibuat:\n%s", main)
+			}
+		})
+	}
+}
+
+// Delivery GraphQL yang ditambahkan pada modul yang sudah ada memakai
+// usecase modul tersebut, dan endpoint /graphql hanya didaftarkan sekali
+func TestRegisterGraphQLDelivery(t *testing.T) {
+	for _, framework := range []string{"mux", "echo"} {
+		t.Run(framework, func(t *testing.T) {
+			project := newProject(t, framework)
+			specs := []string{"name:string", "password:string:writeonly"}
+			generateDelivery(t, project, "product", nil, specs...)
+			generateDelivery(t, project, "product", []string{"http", "graphql"}, specs...)
+			generateDelivery(t, project, "category", []string{"graphql"}, "name:string")
+			generateDelivery(t, project, "product", []string{"http", "graphql"}, specs...)
+
+			schema := readFile(t, filepath.Join(project.Root, "internal", "delivery", "graphql", "schema", "product.graphql"))
+			for _, want := range []string{
+				"input ProductInput {\n  name: String!\n  password: String!\n}",
+				"input ProductUpdateInput {\n  name: String!\n  password: String\n}",
+				"updateProduct(id: ID!, input: ProductUpdateInput!): Product!",
+			} {
+				if !strings.Contains(schema, want) {
+					t.Errorf("product.graphql tidak memuat %q:\n%s", want, schema)
+				}
+			}
+
+			resolver := readFile(t, filepath.Join(project.Root, "internal", "delivery", "graphql", "resolver.go"))
+			for _, want := range []string{"\t*ProductResolver\n", "\t*CategoryResolver\n"} {
+				if n := strings.Count(resolver, want); n != 1 {
+					t.Errorf("resolver.go memuat %q %d kali, want 1:\n%s", want, n, resolver)
+				}
+			}
+
+			main := readFile(t, filepath.Join(project.Root, "cmd", "main.go"))
+			for _, want := range []string{
+				"productRepository := repository.NewProductRepository(db)",
+				"productUsecase := usecase.NewProductUsecase(productRepository)",
+				"productHandler.RegisterRoutes(",
+				"graphqlResolver := &deliverygraphql.Resolver{}",
+				"deliverygraphql.Register(",
+				"graphqlResolver.ProductResolver = deliverygraphql.NewProductResolver(productUsecase)",
+				"graphqlResolver.CategoryResolver = deliverygraphql.NewCategoryResolver(categoryUsecase)",
+			} {
+				if n := strings.Count(main, want); n != 1 {
+					t.Errorf("main.go memuat %q %d kali, want 1:\n%s", want, n, main)
+				}
+			}
+			if strings.Index(main, "productUsecase :=") > strings.Index(main, "graphqlResolver.ProductResolver") {
+				t.Errorf("resolver product dibuat sebelum usecase:\n%s", main)
+			}
+		})
+	}
+}
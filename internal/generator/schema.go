@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+)
+
+// table adalah struktur tabel yang tidak bergantung pada dialect SQL. Tipe
+// kolom memakai nama tipe pada field spec (string, decimal, time, ...).
+type table struct {
+	Name    string   `json:"name"`
+	Columns []column `json:"columns"`
+	Indexes []index  `json:"indexes,omitempty"`
+}
+
+// column adalah satu kolom tabel
+type column struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Size       string `json:"size,omitempty"` // panjang string atau presisi decimal; kosong berarti bawaan
+	Nullable   bool   `json:"nullable,omitempty"`
+	PrimaryKey bool   `json:"primary_key,omitempty"`
+	Unique     bool   `json:"unique,omitempty"` // constraint UNIQUE pada kolom, bukan index terpisah
+	Default    string `json:"default,omitempty"`
+	HasDefault bool   `json:"has_default,omitempty"`
+	References string `json:"references,omitempty"` // tabel yang dirujuk foreign key
+	OnDelete   string `json:"on_delete,omitempty"`  // aksi foreign key, mis. CASCADE
+	OnUpdate   string `json:"on_update,omitempty"`
+
+	sqlType string // tipe asli dari database atau DDL, untuk pesan peringatan
+}
+
+// index adalah index pada satu atau beberapa kolom
+type index struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique,omitempty"`
+}
+
+// TableSpec mengatur tabel entity modul yang tidak mengikuti konvensi capy,
+// mis. tabel lama yang dibaca dari database. Nilai kosong berarti konvensi
+// bawaan: tabel snake_case jamak dengan kolom id, created_at, dan updated_at.
+type TableSpec struct {
+	Name         string // nama tabel, kosong berarti nama bawaan GORM
+	PrimaryKey   string // kolom primary key, kosong berarti id
+	NoTimestamps bool   // tabel tidak memiliki kolom created_at dan updated_at
+	Exists       bool   // tabel sudah ada di database sehingga migration create tidak dibuat
+}
+
+// table mengembalikan nama tabel modul name sesuai spec
+func (s TableSpec) table(name string) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return tableName(name)
+}
+
+// primaryKey mengembalikan kolom primary key sesuai spec
+func (s TableSpec) primaryKey() string {
+	if s.PrimaryKey != "" {
+		return s.PrimaryKey
+	}
+	return "id"
+}
+
+// tableFromFields membentuk tabel modul name dari field-nya, termasuk kolom
+// id, created_at, dan updated_at yang dibuat oleh template entity
+func tableFromFields(name string, spec TableSpec, fields []Field) table {
+	t := table{Name: spec.table(name)}
+	t.Columns = append(t.Columns, column{Name: spec.primaryKey(), Type: "uint", PrimaryKey: true})
+
+	for _, f := range fields {
+		t.Columns = append(t.Columns, column{
+			Name:       f.Column,
+			Type:       f.Type,
+			Size:       f.Size,
+			Nullable:   f.Nullable,
+			Unique:     f.Unique && f.NoIndex,
+			Default:    f.Default,
+			HasDefault: f.HasDefault,
+			References: f.References(),
+			OnDelete:   f.OnDelete,
+			OnUpdate:   f.OnUpdate,
+		})
+		name := f.IndexName
+		if name == "" {
+			name = indexName(t.Name, f.Column)
+		}
+		switch {
+		case f.Unique && f.NoIndex:
+			// constraint UNIQUE sudah menjadi bagian definisi kolom
+		case f.Unique:
+			t.Indexes = append(t.Indexes, index{Name: name, Columns: []string{f.Column}, Unique: true})
+		case f.Index || f.ForeignKey && !f.NoIndex:
+			t.Indexes = append(t.Indexes, index{Name: name, Columns: []string{f.Column}})
+		}
+	}
+
+	if !spec.NoTimestamps {
+		t.Columns = append(t.Columns,
+			column{Name: "created_at", Type: "time", Nullable: true},
+			column{Name: "updated_at", Type: "time", Nullable: true},
+		)
+	}
+	return t
+}
+
+// defaultSizes adalah ukuran bawaan tipe kolom yang dapat diubah panjang
+// atau presisinya, sesuai tipe pada sqlDialect
+var defaultSizes = map[string]string{
+	"string":  "255",
+	"decimal": "12,2",
+}
+
+// columnSize menormalkan parameter tipe SQL, mis. "10, 2" pada DECIMAL(10, 2),
+// menjadi ukuran kolom bertipe typ. String kosong dikembalikan jika tipe
+// tidak memiliki ukuran, parameter tidak valid, atau sama dengan bawaan.
+func columnSize(typ, params string) string {
+	def, ok := defaultSizes[typ]
+	if !ok {
+		return ""
+	}
+	parts := strings.Split(strings.ReplaceAll(params, " ", ""), ",")
+	if typ == "decimal" && len(parts) == 1 {
+		parts = append(parts, "0")
+	}
+	if len(parts) != strings.Count(def, ",")+1 {
+		return ""
+	}
+	for _, p := range parts {
+		if _, err := strconv.ParseUint(p, 10, 32); err != nil {
+			return ""
+		}
+	}
+	if size := strings.Join(parts, ","); size != def {
+		return size
+	}
+	return ""
+}
+
+// sqlTypeSize mengambil ukuran kolom bertipe typ dari tipe SQL, mis.
+// 10,2 dari DECIMAL(10,2)
+func sqlTypeSize(typ, sqlType string) string {
+	_, rest, ok := strings.Cut(sqlType, "(")
+	if !ok {
+		return ""
+	}
+	params, _, _ := strings.Cut(rest, ")")
+	return columnSize(typ, params)
+}
+
+// indexName mengikuti penamaan index bawaan GORM, mis. idx_products_sku
+func indexName(table string, columns ...string) string {
+	return "idx_" + table + "_" + strings.Join(columns, "_")
+}
+
+// foreignKeyName adalah nama constraint foreign key kolom pada tabel
+func foreignKeyName(table, column string) string {
+	return "fk_" + table + "_" + column
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// snapshotFile adalah file snapshot skema di dalam direktori migration.
+// golang-migrate mengabaikan file ini karena namanya bukan nama migration.
+const snapshotFile = ".capy_schema.json"
+
+// schemaSnapshot adalah skema database setelah migration terakhir yang
+// dibuat capy, dipakai sebagai pembanding oleh capy migrate diff
+type schemaSnapshot struct {
+	Tables []table `json:"tables"`
+}
+
+// loadSnapshot membaca snapshot pada dir, mengutamakan isi di set. ok
+// bernilai false jika snapshot belum pernah dibuat.
+func loadSnapshot(set *FileSet, dir string) (snapshot *schemaSnapshot, ok bool, err error) {
+	path := filepath.Join(dir, snapshotFile)
+	content, ok, err := currentContent(set, path)
+	if err != nil || !ok {
+		return &schemaSnapshot{}, false, err
+	}
+
+	snapshot = &schemaSnapshot{}
+	if err := json.Unmarshal(content, snapshot); err != nil {
+		return nil, false, fmt.Errorf("gagal membaca snapshot skema %s: %w", displayPath(path), err)
+	}
+	return snapshot, true, nil
+}
+
+// save menulis snapshot ke dalam set dengan tabel terurut berdasarkan nama.
+// Snapshot hanya ditulis jika seluruh file requires ikut ditulis, sehingga
+// snapshot tidak mencatat skema dari entity atau migration yang dilewati.
+func (s *schemaSnapshot) save(set *FileSet, dir string, requires ...string) error {
+	sort.Slice(s.Tables, func(i, j int) bool { return s.Tables[i].Name < s.Tables[j].Name })
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan snapshot skema: %w", err)
+	}
+	path := filepath.Join(dir, snapshotFile)
+	set.Update(path, append(content, '\n'))
+	set.Require(path, requires...)
+	return nil
+}
+
+// table mengembalikan tabel bernama name pada snapshot
+func (s *schemaSnapshot) table(name string) (table, bool) {
+	for _, t := range s.Tables {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return table{}, false
+}
+
+// put menambahkan tabel atau menggantikan tabel dengan nama yang sama
+func (s *schemaSnapshot) put(t table) {
+	for i := range s.Tables {
+		if s.Tables[i].Name == t.Name {
+			s.Tables[i] = t
+			return
+		}
+	}
+	s.Tables = append(s.Tables, t)
+}
@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/jinzhu/inflection"
+)
+
+// builtinTemplates berisi template bawaan capy
+//
+//go:embed all:templates
+var builtinTemplates embed.FS
+
+// templateFuncs adalah fungsi yang tersedia di semua template
+var templateFuncs = template.FuncMap{
+	"lower":  strings.ToLower,
+	"upper":  strings.ToUpper,
+	"pascal": toPascal,
+	"camel":  toCamel,
+	"snake":  toSnake,
+	"plural": inflection.Plural,
+	"join":   strings.Join,
+
+	"httpStatus": httpStatusConst,
+}
+
+// Renderer me-render template dengan urutan pencarian: template proyek
+// (.capy/templates), template user (~/.config/capy/templates), lalu
+// template bawaan capy
+type Renderer struct {
+	dirs       []string
+	modulePath string
+}
+
+// NewRenderer membuat Renderer untuk proyek pada projectRoot. modulePath
+// dipakai untuk mengelompokkan import package proyek pada file Go.
+func NewRenderer(projectRoot, modulePath string) *Renderer {
+	var dirs []string
+	if projectRoot != "" {
+		dirs = append(dirs, filepath.Join(projectRoot, ".capy", "templates"))
+	}
+	if configDir, err := os.UserConfigDir(); err == nil {
+		dirs = append(dirs, filepath.Join(configDir, "capy", "templates"))
+	}
+	return &Renderer{dirs: dirs, modulePath: modulePath}
+}
+
+// Render me-render template name (mis. "module/handler.go.tmpl") dengan data
+func (r *Renderer) Render(name string, data interface{}) ([]byte, error) {
+	content, _, err := r.render(name, data)
+	return content, err
+}
+
+// RenderFile me-render template untuk file target. File Go dirapikan dengan
+// gofmt dan pembersihan import, dan gagal jika hasil render bukan Go yang valid.
+func (r *Renderer) RenderFile(name, target string, data interface{}) ([]byte, error) {
+	content, source, err := r.render(name, data)
+	if err != nil || filepath.Ext(target) != ".go" {
+		return content, err
+	}
+
+	formatted, err := formatGo(content, r.modulePath)
+	if err != nil {
+		return nil, goSyntaxError(source, target, content, err)
+	}
+	return formatted, nil
+}
+
+func (r *Renderer) render(name string, data interface{}) ([]byte, string, error) {
+	content, source, err := r.lookup(name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	t, err := template.New(name).Funcs(templateFuncs).Parse(string(content))
+	if err != nil {
+		return nil, "", fmt.Errorf("gagal parse template %s: %w", source, err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, "", fmt.Errorf("gagal render template %s: %w", source, err)
+	}
+	return buf.Bytes(), source, nil
+}
+
+// lookup mengembalikan isi template beserta lokasi asalnya
+func (r *Renderer) lookup(name string) ([]byte, string, error) {
+	for _, dir := range r.dirs {
+		source := filepath.Join(dir, filepath.FromSlash(name))
+		content, err := os.ReadFile(source)
+		if err == nil {
+			return content, source, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, "", fmt.Errorf("gagal membaca template %s: %w", source, err)
+		}
+	}
+
+	source := path.Join("templates", name)
+	content, err := builtinTemplates.ReadFile(source)
+	if err != nil {
+		return nil, "", fmt.Errorf("template %s tidak ditemukan", name)
+	}
+	return content, source, nil
+}
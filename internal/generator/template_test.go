@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTemplate menulis template name pada direktori template dir
+func writeTemplate(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRendererLookup(t *testing.T) {
+	const name = "module/entity.go.tmpl"
+	builtin, err := builtinTemplates.ReadFile("templates/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		project     string // isi template proyek; kosong berarti tidak ada
+		user        string // isi template user; kosong berarti tidak ada
+		want        string
+		wantProject bool
+		wantUser    bool
+	}{
+		{name: "proyek diutamakan", project: "proyek", user: "user", want: "proyek", wantProject: true},
+		{name: "user jika proyek tidak ada", user: "user", want: "user", wantUser: true},
+		{name: "bawaan jika tidak ada override", want: string(builtin)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, user := t.TempDir(), t.TempDir()
+			if tt.project != "" {
+				writeTemplate(t, project, name, tt.project)
+			}
+			if tt.user != "" {
+				writeTemplate(t, user, name, tt.user)
+			}
+			// Template lain pada direktori proyek tidak memengaruhi pencarian
+			writeTemplate(t, project, "module/dto.go.tmpl", "lain")
+
+			r := &Renderer{dirs: []string{project, user}}
+			content, source, err := r.lookup(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("isi = %q, want %q", content, tt.want)
+			}
+			switch {
+			case tt.wantProject:
+				if !strings.HasPrefix(source, project) {
+					t.Errorf("source = %s, want dari %s", source, project)
+				}
+			case tt.wantUser:
+				if !strings.HasPrefix(source, user) {
+					t.Errorf("source = %s, want dari %s", source, user)
+				}
+			default:
+				if source != "templates/"+name {
+					t.Errorf("source = %s, want templates/%s", source, name)
+				}
+			}
+		})
+	}
+}
+
+func TestRendererLookupErrors(t *testing.T) {
+	dir := t.TempDir()
+	// Direktori dengan nama template tidak dapat dibaca sebagai file
+	if err := os.MkdirAll(filepath.Join(dir, "module", "entity.go.tmpl"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	r := &Renderer{dirs: []string{dir}}
+
+	if _, _, err := r.lookup("module/entity.go.tmpl"); err == nil || !strings.Contains(err.Error(), "gagal membaca template") {
+		t.Errorf("error = %v, want gagal membaca template", err)
+	}
+	if _, _, err := r.lookup("module/missing.go.tmpl"); err == nil || !strings.Contains(err.Error(), "template module/missing.go.tmpl tidak ditemukan") {
+		t.Errorf("error = %v, want tidak ditemukan", err)
+	}
+}
+
+// Override yang menghasilkan Go tidak valid dilaporkan dengan lokasi
+// template asalnya
+func TestRendererRenderFileOverride(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "module/entity.go.tmpl", "package entity\n\ntype {{.}} struct {\n")
+	r := &Renderer{dirs: []string{dir}, modulePath: "example.com/shop"}
+
+	_, err := r.RenderFile("module/entity.go.tmpl", filepath.Join(dir, "product.go"), "Product")
+	source := filepath.Join(dir, "module", "entity.go.tmpl")
+	if err == nil || !strings.Contains(err.Error(), "template "+source+" menghasilkan kode Go yang tidak valid") {
+		t.Errorf("error = %v, want error dari %s", err, source)
+	}
+}
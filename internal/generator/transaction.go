@@ -0,0 +1,209 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// transaction menulis file secara all-or-nothing: semua file ditulis dulu ke
+// direktori staging, baru dipindahkan ke lokasi akhir. Jika ada langkah yang
+// gagal, semua perubahan (file baru, file yang ditimpa, dan direktori yang
+// dibuat) dikembalikan seperti semula.
+type transaction struct {
+	staging     string
+	createdDirs []string
+	written     []string
+	backups     map[string]string // path asli -> path backup di staging
+}
+
+// stagedFile adalah file yang sudah ditulis ke staging dan siap dipindahkan
+type stagedFile struct {
+	target string
+	staged string
+}
+
+// commitFiles menulis files dan membuat dirs dalam satu transaksi
+func commitFiles(dirs []string, files []*File, targets []string) (err error) {
+	if len(files) == 0 && len(dirs) == 0 {
+		return nil
+	}
+
+	tx := &transaction{backups: make(map[string]string)}
+	staging, err := os.MkdirTemp(stagingBase(append(append([]string{}, dirs...), targets...)), ".capy-staging-*")
+	if err != nil {
+		return fmt.Errorf("gagal membuat direktori staging: %w", err)
+	}
+	tx.staging = staging
+	defer os.RemoveAll(staging)
+
+	defer func() {
+		if err != nil {
+			if rbErr := tx.rollback(); rbErr != nil {
+				err = fmt.Errorf("%w (rollback gagal: %v)", err, rbErr)
+			}
+		}
+	}()
+
+	// Tahap 1: tulis semua isi file ke staging
+	staged := make([]stagedFile, 0, len(files))
+	for i, f := range files {
+		path := filepath.Join(staging, "files", strconv.Itoa(i))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("gagal menyiapkan staging: %w", err)
+		}
+		if err := os.WriteFile(path, f.Content, f.Mode); err != nil {
+			return fmt.Errorf("gagal menulis %s ke staging: %w", targets[i], err)
+		}
+		staged = append(staged, stagedFile{target: targets[i], staged: path})
+	}
+
+	// Tahap 2: buat direktori lalu pindahkan file ke lokasi akhir
+	for _, dir := range dirs {
+		if err := tx.mkdirAll(dir); err != nil {
+			return err
+		}
+	}
+	for i, s := range staged {
+		if err := tx.mkdirAll(filepath.Dir(s.target)); err != nil {
+			return err
+		}
+		if err := tx.backup(s.target, i); err != nil {
+			return err
+		}
+		if err := moveFile(s.staged, s.target); err != nil {
+			return fmt.Errorf("gagal membuat file: %s: %w", s.target, err)
+		}
+		tx.written = append(tx.written, s.target)
+	}
+
+	return nil
+}
+
+// mkdirAll membuat dir beserta induknya dan mencatat direktori yang baru dibuat
+func (tx *transaction) mkdirAll(dir string) error {
+	var missing []string
+	for d := filepath.Clean(dir); ; d = filepath.Dir(d) {
+		if info, err := os.Stat(d); err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("gagal membuat direktori %s: %s bukan direktori", dir, d)
+			}
+			break
+		}
+		missing = append(missing, d)
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+
+	for i := len(missing) - 1; i >= 0; i-- {
+		if err := os.Mkdir(missing[i], 0755); err != nil && !errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("gagal membuat direktori %s: %w", missing[i], err)
+		}
+		tx.createdDirs = append(tx.createdDirs, missing[i])
+	}
+	return nil
+}
+
+// backup memindahkan file yang akan ditimpa ke staging agar dapat dikembalikan
+func (tx *transaction) backup(target string, i int) error {
+	if _, err := os.Lstat(target); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	path := filepath.Join(tx.staging, "backup", strconv.Itoa(i))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("gagal menyiapkan backup: %w", err)
+	}
+	if err := moveFile(target, path); err != nil {
+		return fmt.Errorf("gagal membuat backup %s: %w", target, err)
+	}
+	tx.backups[target] = path
+	return nil
+}
+
+// rollback membatalkan semua perubahan yang sudah dilakukan transaksi
+func (tx *transaction) rollback() error {
+	var errs []error
+
+	for i := len(tx.written) - 1; i >= 0; i-- {
+		if err := os.Remove(tx.written[i]); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	for target, backup := range tx.backups {
+		if err := moveFile(backup, target); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for i := len(tx.createdDirs) - 1; i >= 0; i-- {
+		if err := os.Remove(tx.createdDirs[i]); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// moveFile memindahkan file, dengan fallback salin-lalu-hapus jika rename
+// tidak dapat dilakukan, mis. antar filesystem
+func moveFile(from, to string) error {
+	if err := os.Rename(from, to); err == nil {
+		return nil
+	}
+
+	info, err := os.Stat(from)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(from)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(to, content, info.Mode().Perm()); err != nil {
+		return err
+	}
+	return os.Remove(from)
+}
+
+// stagingBase mencari direktori yang sudah ada dan menjadi induk bersama
+// semua path, sehingga staging berada di filesystem yang sama dengan target
+func stagingBase(paths []string) string {
+	var base string
+	for _, p := range paths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			continue
+		}
+		if base == "" {
+			base = filepath.Dir(abs)
+			continue
+		}
+		for !isWithin(abs, base) {
+			base = filepath.Dir(base)
+		}
+	}
+	if base == "" {
+		return os.TempDir()
+	}
+
+	for {
+		if info, err := os.Stat(base); err == nil && info.IsDir() {
+			return base
+		}
+		parent := filepath.Dir(base)
+		if parent == base {
+			return os.TempDir()
+		}
+		base = parent
+	}
+}
+
+// isWithin menandakan path berada di dalam dir
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !filepath.IsAbs(rel) && (len(rel) < 3 || rel[:3] != ".."+string(filepath.Separator))
+}
@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// listTree mengembalikan path relatif semua file dan direktori di bawah root
+// beserta isi file
+func listTree(t *testing.T, root string) map[string]string {
+	t.Helper()
+	tree := make(map[string]string)
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil || path == root {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		if d.IsDir() {
+			tree[filepath.ToSlash(rel)+"/"] = ""
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		tree[filepath.ToSlash(rel)] = string(content)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tree
+}
+
+func TestCommitFilesRollback(t *testing.T) {
+	tests := []struct {
+		name    string
+		dirs    []string
+		files   []string // path relatif; isi file adalah "baru " + path
+		wantErr string
+	}{
+		{
+			name:    "gagal membuat direktori file terakhir",
+			files:   []string{"internal/entity/product.go", "main.go", "README.md/product.go"},
+			wantErr: "README.md bukan direktori",
+		},
+		{
+			name:    "gagal membuat direktori kosong",
+			dirs:    []string{"internal/dto", "README.md/docs"},
+			files:   []string{"main.go"},
+			wantErr: "README.md bukan direktori",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, map[string]string{"main.go": "lama\n", "README.md": "readme\n"})
+			before := listTree(t, root)
+
+			var dirs, targets []string
+			var files []*File
+			for _, d := range tt.dirs {
+				dirs = append(dirs, filepath.Join(root, d))
+			}
+			for _, name := range tt.files {
+				target := filepath.Join(root, filepath.FromSlash(name))
+				files = append(files, &File{Path: target, Content: []byte("baru " + name), Mode: 0644})
+				targets = append(targets, target)
+			}
+
+			err := commitFiles(dirs, files, targets)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			// File baru, direktori baru, dan staging dihapus; file yang
+			// ditimpa kembali ke isi lamanya
+			if after := listTree(t, root); !reflect.DeepEqual(after, before) {
+				t.Errorf("isi direktori setelah rollback = %v, want %v", after, before)
+			}
+		})
+	}
+}
+
+func TestCommitFiles(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"main.go": "lama\n"})
+
+	files := []*File{
+		{Path: filepath.Join(root, "internal", "entity", "product.go"), Content: []byte("entity\n"), Mode: 0644},
+		{Path: filepath.Join(root, "main.go"), Content: []byte("baru\n"), Mode: 0644},
+	}
+	targets := []string{files[0].Path, files[1].Path + ".new"}
+	if err := commitFiles([]string{filepath.Join(root, "migrations")}, files, targets); err != nil {
+		t.Fatal(err)
+	}
+
+	got := listTree(t, root)
+	want := map[string]string{
+		"internal/":                  "",
+		"internal/entity/":           "",
+		"internal/entity/product.go": "entity\n",
+		"main.go":                    "lama\n",
+		"main.go.new":                "baru\n",
+		"migrations/":                "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("isi direktori = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,238 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// setRule mengisi aturan validasi field dari modifier field spec, mis.
+// min=3 atau enum=draft|published. ok bernilai false jika key bukan
+// modifier validasi.
+func (f *Field) setRule(key, value string, hasValue bool) (ok bool, err error) {
+	switch key {
+	case "required":
+		f.Required = true
+	case "email":
+		f.Email = true
+	case "min", "max", "regex", "enum":
+		if !hasValue || value == "" {
+			return true, fmt.Errorf("modifier %s membutuhkan nilai, mis. %s", key, ruleExamples[key])
+		}
+		switch key {
+		case "min":
+			f.Min = value
+		case "max":
+			f.Max = value
+		case "regex":
+			if _, err := regexp.Compile(value); err != nil {
+				return true, fmt.Errorf("regex %q tidak valid: %w", value, err)
+			}
+			f.Pattern = value
+		case "enum":
+			f.Enum = strings.Split(value, "|")
+		}
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+var ruleExamples = map[string]string{
+	"min":   "min=3",
+	"max":   "max=255",
+	"regex": "regex=^[A-Z]+$",
+	"enum":  "enum=draft|published",
+}
+
+// checkRules memastikan aturan validasi sesuai dengan tipe field
+func (f Field) checkRules() error {
+	isString := f.isString()
+	isNumber := f.isNumber()
+	if f.Required && f.Type == "bool" && !f.Nullable {
+		return fmt.Errorf("modifier required tidak berlaku untuk field bool yang tidak null")
+	}
+	if (f.Email || f.Pattern != "") && !isString {
+		return fmt.Errorf("modifier email dan regex hanya berlaku untuk field string atau text")
+	}
+	for _, bound := range []string{f.Min, f.Max} {
+		if bound == "" {
+			continue
+		}
+		if !isString && !isNumber {
+			return fmt.Errorf("modifier min dan max hanya berlaku untuk field string, text, atau angka")
+		}
+		if err := f.checkNumber(bound, isString); err != nil {
+			return err
+		}
+	}
+	if len(f.Enum) > 0 {
+		if !isString && !isNumber {
+			return fmt.Errorf("modifier enum hanya berlaku untuk field string, text, atau angka")
+		}
+		for _, v := range f.Enum {
+			if isNumber {
+				if err := f.checkNumber(v, false); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkNumber memastikan v adalah angka yang dapat dipakai pada field.
+// Batas panjang string dan nilai field uint selalu berupa bilangan bulat
+// tidak negatif, karena kode validasi yang dibuat tidak dapat di-compile
+// jika konstanta negatif dibandingkan dengan nilai uint.
+func (f Field) checkNumber(v string, length bool) error {
+	switch {
+	case length || f.Type == "uint":
+		if _, err := strconv.ParseUint(v, 10, 64); err != nil {
+			return fmt.Errorf("nilai %q bukan bilangan bulat tidak negatif", v)
+		}
+	case f.Type != "float" && f.Type != "decimal":
+		if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+			return fmt.Errorf("nilai %q bukan bilangan bulat", v)
+		}
+	default:
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return fmt.Errorf("nilai %q bukan angka", v)
+		}
+	}
+	return nil
+}
+
+func (f Field) isString() bool {
+	return f.Type == "string" || f.Type == "text"
+}
+
+func (f Field) isNumber() bool {
+	switch f.Type {
+	case "int", "int64", "uint", "float", "decimal":
+		return true
+	}
+	return false
+}
+
+// HasRules menandakan field memiliki aturan validasi
+func (f Field) HasRules() bool {
+	return f.Required || f.Email || f.Min != "" || f.Max != "" || f.Pattern != "" || len(f.Enum) > 0
+}
+
+// fieldValidation adalah kode validasi satu field pada usecase modul
+type fieldValidation struct {
+	Field      Field
+	PatternVar string // variabel regexp untuk modifier regex
+	PatternLit string // literal Go pola regex
+	Checks     []validationCheck
+}
+
+// validationCheck adalah satu aturan yang gagal jika Cond bernilai true
+type validationCheck struct {
+	Cond    string
+	Message string
+}
+
+// Validations mengembalikan kode validasi field yang memiliki aturan,
+// dipakai usecase sebelum Create dan Update
+func (d moduleData) Validations() []fieldValidation {
+	var out []fieldValidation
+	for _, f := range d.Fields {
+		if !f.HasRules() {
+			continue
+		}
+		v := fieldValidation{Field: f}
+		if f.Pattern != "" {
+			v.PatternVar = toCamel(d.LowerName) + f.Name + "Pattern"
+			v.PatternLit = goStringLit(f.Pattern)
+		}
+		v.Checks = f.checks(d.LowerName+"."+f.Name, v.PatternVar)
+		out = append(out, v)
+	}
+	return out
+}
+
+// checks menghasilkan kondisi gagal untuk setiap aturan field. Field
+// nullable yang bernilai nil hanya diperiksa oleh modifier required, field
+// ber-default yang bernilai nil tidak diperiksa karena memakai default, dan
+// string kosong yang tidak required tidak diperiksa aturan lainnya.
+func (f Field) checks(value, patternVar string) []validationCheck {
+	var checks []validationCheck
+	add := func(cond, message string) {
+		checks = append(checks, validationCheck{cond, message})
+	}
+
+	guard := ""
+	if f.Pointer() {
+		if f.Required && f.Nullable {
+			add(value+" == nil", "is required")
+		}
+		guard = value + " != nil && "
+		value = "*" + value
+	}
+	if f.Required && !f.Nullable {
+		switch {
+		case f.isString():
+			add(guard+value+` == ""`, "is required")
+		case f.IsTime():
+			add(guard+value+".IsZero()", "is required")
+		default:
+			add(guard+value+" == 0", "is required")
+		}
+	}
+	// String opsional yang kosong berarti tidak diisi, sehingga aturan lain
+	// hanya diperiksa untuk nilai yang tidak kosong
+	if f.isString() && !f.Required {
+		guard += value + ` != "" && `
+	}
+
+	if f.isString() {
+		length := "utf8.RuneCountInString(" + value + ")"
+		if f.Min != "" {
+			add(guard+length+" < "+f.Min, "must be at least "+f.Min+" characters")
+		}
+		if f.Max != "" {
+			add(guard+length+" > "+f.Max, "must be at most "+f.Max+" characters")
+		}
+	} else {
+		if f.Min != "" {
+			add(guard+value+" < "+f.Min, "must be at least "+f.Min)
+		}
+		if f.Max != "" {
+			add(guard+value+" > "+f.Max, "must be at most "+f.Max)
+		}
+	}
+	if f.Email {
+		add(guard+"!validation.IsEmail("+value+")", "must be a valid email address")
+	}
+	if f.Pattern != "" {
+		add(guard+"!"+patternVar+".MatchString("+value+")", "must match pattern "+f.Pattern)
+	}
+	if len(f.Enum) > 0 {
+		var conds []string
+		for _, e := range f.Enum {
+			lit := e
+			if f.isString() {
+				lit = strconv.Quote(e)
+			}
+			conds = append(conds, value+" != "+lit)
+		}
+		cond := strings.Join(conds, " && ")
+		if guard != "" {
+			cond = guard + "(" + cond + ")"
+		}
+		add(cond, "must be one of: "+strings.Join(f.Enum, ", "))
+	}
+	return checks
+}
+
+// goStringLit menulis s sebagai raw string literal jika memungkinkan agar
+// pola regex mudah dibaca
+func goStringLit(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFieldChecks(t *testing.T) {
+	tests := []struct {
+		spec string
+		want []validationCheck
+	}{
+		{
+			spec: "email:string:email",
+			want: []validationCheck{{`p.Email != "" && !validation.IsEmail(p.Email)`, "must be a valid email address"}},
+		},
+		{
+			spec: "email:string:required:email",
+			want: []validationCheck{
+				{`p.Email == ""`, "is required"},
+				{`!validation.IsEmail(p.Email)`, "must be a valid email address"},
+			},
+		},
+		{
+			spec: "name:string:min=2:max=10",
+			want: []validationCheck{
+				{`p.Name != "" && utf8.RuneCountInString(p.Name) < 2`, "must be at least 2 characters"},
+				{`p.Name != "" && utf8.RuneCountInString(p.Name) > 10`, "must be at most 10 characters"},
+			},
+		},
+		{
+			spec: "note:string:null:regex=^[a-z]+$",
+			want: []validationCheck{{`p.Note != nil && *p.Note != "" && !notePattern.MatchString(*p.Note)`, "must match pattern ^[a-z]+$"}},
+		},
+		{
+			spec: "status:string:default=draft:enum=draft|published",
+			want: []validationCheck{{`p.Status != nil && *p.Status != "" && (*p.Status != "draft" && *p.Status != "published")`, "must be one of: draft, published"}},
+		},
+		{
+			spec: "qty:int:min=0",
+			want: []validationCheck{{`p.Qty < 0`, "must be at least 0"}},
+		},
+		{
+			spec: "stock:int:default=5:required:max=10",
+			want: []validationCheck{
+				{`p.Stock != nil && *p.Stock == 0`, "is required"},
+				{`p.Stock != nil && *p.Stock > 10`, "must be at most 10"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			f, err := ParseField(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := f.checks("p."+f.Name, toCamel(f.Name)+"Pattern"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checks =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
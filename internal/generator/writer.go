@@ -0,0 +1,240 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ConflictMode menentukan tindakan ketika file yang akan ditulis sudah ada
+type ConflictMode int
+
+const (
+	// ConflictAsk menanyakan tindakan secara interaktif untuk setiap file
+	ConflictAsk ConflictMode = iota
+	// ConflictOverwrite selalu menimpa file yang sudah ada
+	ConflictOverwrite
+	// ConflictSkip selalu melewati file yang sudah ada
+	ConflictSkip
+)
+
+// fileAction adalah tindakan yang akan dilakukan terhadap satu file
+type fileAction int
+
+const (
+	actionCreate fileAction = iota
+	actionOverwrite
+	actionSkip
+	actionWriteNew
+	actionUnchanged
+)
+
+// plannedFile adalah file beserta tindakan hasil resolusi konflik
+type plannedFile struct {
+	file   *File
+	action fileAction
+}
+
+// Writer menulis FileSet ke disk secara all-or-nothing. File yang sudah ada
+// tidak pernah ditimpa diam-diam: tindakannya ditentukan oleh Conflict. Pada
+// mode dry-run Writer hanya menampilkan daftar file beserta unified diff
+// terhadap isi di disk.
+type Writer struct {
+	DryRun      bool
+	Conflict    ConflictMode
+	Interactive bool
+	In          io.Reader
+	Out         io.Writer
+}
+
+// NewWriter membuat Writer yang membaca jawaban dari stdin dan mencetak ke stdout
+func NewWriter() *Writer {
+	return &Writer{
+		Interactive: isTerminal(os.Stdin),
+		In:          os.Stdin,
+		Out:         os.Stdout,
+	}
+}
+
+// Write menulis seluruh isi FileSet
+func (w *Writer) Write(set *FileSet) error {
+	if w.DryRun {
+		return w.preview(set)
+	}
+
+	plan, err := w.resolve(set)
+	if err != nil {
+		return err
+	}
+
+	var files []*File
+	var targets []string
+	for _, p := range plan {
+		path := p.file.Path
+		switch p.action {
+		case actionSkip:
+			fmt.Fprintf(w.Out, "  skip       %s\n", displayPath(path))
+			continue
+		case actionUnchanged:
+			continue
+		case actionWriteNew:
+			path += ".new"
+			fmt.Fprintf(w.Out, "  new        %s\n", displayPath(path))
+		}
+		files = append(files, p.file)
+		targets = append(targets, path)
+	}
+
+	return commitFiles(set.Dirs(), files, targets)
+}
+
+// resolve menentukan tindakan untuk setiap file sebelum ada yang ditulis,
+// sehingga pertanyaan interaktif selesai sebelum disk berubah
+func (w *Writer) resolve(set *FileSet) ([]plannedFile, error) {
+	var plan []plannedFile
+	var reader *bufio.Reader
+	actions := make(map[string]fileAction)
+
+	for _, f := range set.Files() {
+		if skipped(f, actions) {
+			actions[f.Path] = actionSkip
+			plan = append(plan, plannedFile{file: f, action: actionSkip})
+			continue
+		}
+
+		existing, err := os.ReadFile(f.Path)
+		if errors.Is(err, fs.ErrNotExist) {
+			actions[f.Path] = actionCreate
+			plan = append(plan, plannedFile{file: f, action: actionCreate})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca %s: %w", f.Path, err)
+		}
+		if bytes.Equal(existing, f.Content) {
+			actions[f.Path] = actionUnchanged
+			plan = append(plan, plannedFile{file: f, action: actionUnchanged})
+			continue
+		}
+
+		var action fileAction
+		switch {
+		case f.Update || w.Conflict == ConflictOverwrite:
+			action = actionOverwrite
+		case w.Conflict == ConflictSkip:
+			action = actionSkip
+		case !w.Interactive:
+			return nil, fmt.Errorf("file %s sudah ada, gunakan --force untuk menimpa atau --skip-existing untuk melewatinya", displayPath(f.Path))
+		default:
+			if reader == nil {
+				reader = bufio.NewReader(w.In)
+			}
+			action, err = w.ask(reader, f, existing)
+			if err != nil {
+				return nil, err
+			}
+		}
+		actions[f.Path] = action
+		plan = append(plan, plannedFile{file: f, action: action})
+	}
+
+	return plan, nil
+}
+
+// skipped menandakan f harus dilewati karena file yang dibutuhkannya
+// dilewati atau ditulis sebagai .new
+func skipped(f *File, actions map[string]fileAction) bool {
+	for _, path := range f.Requires {
+		if action, ok := actions[path]; ok && (action == actionSkip || action == actionWriteNew) {
+			return true
+		}
+	}
+	return false
+}
+
+// ask menanyakan tindakan untuk file yang sudah ada
+func (w *Writer) ask(reader *bufio.Reader, f *File, existing []byte) (fileAction, error) {
+	name := displayPath(f.Path)
+	for {
+		fmt.Fprintf(w.Out, "File %s sudah ada. [s]kip, [o]verwrite, [d]iff, tulis sebagai .[n]ew? ", name)
+		answer, err := reader.ReadString('\n')
+		if err != nil && answer == "" {
+			return 0, fmt.Errorf("gagal membaca jawaban untuk %s: %w", name, err)
+		}
+
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "s", "skip":
+			return actionSkip, nil
+		case "o", "overwrite":
+			return actionOverwrite, nil
+		case "n", "new":
+			return actionWriteNew, nil
+		case "d", "diff":
+			fmt.Fprint(w.Out, UnifiedDiff("a/"+name, "b/"+name, existing, f.Content))
+		}
+	}
+}
+
+// preview mencetak daftar file dan diff tanpa menulis apa pun ke disk
+func (w *Writer) preview(set *FileSet) error {
+	var diffs []string
+
+	fmt.Fprintln(w.Out, "Dry run, tidak ada file yang ditulis:")
+	for _, f := range set.Files() {
+		existing, err := os.ReadFile(f.Path)
+		exists := err == nil
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("gagal membaca %s: %w", f.Path, err)
+		}
+
+		name := displayPath(f.Path)
+		switch {
+		case !exists:
+			fmt.Fprintf(w.Out, "  create     %s\n", name)
+			diffs = append(diffs, UnifiedDiff("/dev/null", "b/"+name, nil, f.Content))
+		case bytes.Equal(existing, f.Content):
+			fmt.Fprintf(w.Out, "  unchanged  %s\n", name)
+		default:
+			fmt.Fprintf(w.Out, "  update     %s\n", name)
+			diffs = append(diffs, UnifiedDiff("a/"+name, "b/"+name, existing, f.Content))
+		}
+	}
+
+	for _, d := range diffs {
+		fmt.Fprintln(w.Out)
+		fmt.Fprint(w.Out, d)
+	}
+	return nil
+}
+
+// isTerminal menandakan f terhubung ke terminal sehingga pertanyaan interaktif
+// dapat dijawab. Mode character device saja tidak cukup karena /dev/null juga
+// character device.
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// displayPath menampilkan path relatif terhadap direktori kerja jika memungkinkan
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,177 @@
+package generator
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles menulis files (path relatif terhadap root) ke disk
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Dry run hanya mencetak daftar file dan diff tanpa menyentuh disk
+func TestWriterDryRun(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"same.go": "a\n", "changed.go": "a\nb\n"})
+
+	set := NewFileSet()
+	set.AddDir(filepath.Join(root, "empty"))
+	set.Add(filepath.Join(root, "new.go"), []byte("x\n"))
+	set.Add(filepath.Join(root, "same.go"), []byte("a\n"))
+	set.Add(filepath.Join(root, "changed.go"), []byte("a\nc\n"))
+
+	var out bytes.Buffer
+	w := &Writer{DryRun: true, Out: &out}
+	if err := w.Write(set); err != nil {
+		t.Fatal(err)
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		"create     " + displayPath(filepath.Join(root, "new.go")),
+		"unchanged  " + displayPath(filepath.Join(root, "same.go")),
+		"update     " + displayPath(filepath.Join(root, "changed.go")),
+		"--- /dev/null\n",
+		"-b\n+c\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output tidak memuat %q:\n%s", want, got)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "new.go")); !os.IsNotExist(err) {
+		t.Errorf("new.go ditulis pada dry run")
+	}
+	if _, err := os.Stat(filepath.Join(root, "empty")); !os.IsNotExist(err) {
+		t.Errorf("direktori dibuat pada dry run")
+	}
+	if got := readFile(t, filepath.Join(root, "changed.go")); got != "a\nb\n" {
+		t.Errorf("changed.go = %q, want tidak berubah", got)
+	}
+}
+
+func TestWriterResolve(t *testing.T) {
+	tests := []struct {
+		name        string
+		conflict    ConflictMode
+		interactive bool
+		input       string
+		update      bool // file berupa suntingan file yang sudah ada
+		content     string
+		want        fileAction
+		wantOut     string // potongan output yang diharapkan
+		wantErr     string
+	}{
+		{name: "isi sama", conflict: ConflictAsk, content: "lama\n", want: actionUnchanged},
+		{name: "force", conflict: ConflictOverwrite, content: "baru\n", want: actionOverwrite},
+		{name: "skip-existing", conflict: ConflictSkip, content: "baru\n", want: actionSkip},
+		{name: "suntingan selalu ditimpa", conflict: ConflictSkip, update: true, content: "baru\n", want: actionOverwrite},
+		{name: "tanpa terminal", conflict: ConflictAsk, content: "baru\n", wantErr: "gunakan --force untuk menimpa atau --skip-existing"},
+		{name: "jawab skip", conflict: ConflictAsk, interactive: true, input: "s\n", content: "baru\n", want: actionSkip},
+		{name: "jawab overwrite", conflict: ConflictAsk, interactive: true, input: "overwrite\n", content: "baru\n", want: actionOverwrite},
+		{name: "jawab new", conflict: ConflictAsk, interactive: true, input: "N\n", content: "baru\n", want: actionWriteNew},
+		{name: "diff lalu skip", conflict: ConflictAsk, interactive: true, input: "d\nx\ns\n", content: "baru\n", want: actionSkip, wantOut: "-lama\n+baru\n"},
+		{name: "input habis", conflict: ConflictAsk, interactive: true, content: "baru\n", wantErr: "gagal membaca jawaban"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, map[string]string{"product.go": "lama\n"})
+			path := filepath.Join(root, "product.go")
+			set := NewFileSet()
+			set.Add(filepath.Join(root, "new.go"), []byte("x\n"))
+			if tt.update {
+				set.Update(path, []byte(tt.content))
+			} else {
+				set.Add(path, []byte(tt.content))
+			}
+
+			var out bytes.Buffer
+			w := &Writer{Conflict: tt.conflict, Interactive: tt.interactive, In: strings.NewReader(tt.input), Out: &out}
+			plan, err := w.resolve(set)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(plan) != 2 || plan[0].action != actionCreate {
+				t.Fatalf("plan = %+v, want new.go dibuat", plan)
+			}
+			if plan[1].action != tt.want {
+				t.Errorf("action = %v, want %v", plan[1].action, tt.want)
+			}
+			if !strings.Contains(out.String(), tt.wantOut) {
+				t.Errorf("output = %q, want memuat %q", out.String(), tt.wantOut)
+			}
+		})
+	}
+}
+
+// File yang membutuhkan file lain ikut dilewati jika file tersebut dilewati
+// atau ditulis sebagai .new
+func TestWriterRequires(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  fileAction
+	}{
+		{name: "entity dilewati", input: "s\n", want: actionSkip},
+		{name: "entity ditulis sebagai .new", input: "n\n", want: actionSkip},
+		{name: "entity ditimpa", input: "o\n", want: actionCreate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, map[string]string{"product.go": "lama\n"})
+			entity, snapshot := filepath.Join(root, "product.go"), filepath.Join(root, "schema.json")
+			set := NewFileSet()
+			set.Add(entity, []byte("baru\n"))
+			set.Add(snapshot, []byte("{}\n"))
+			set.Require(snapshot, entity)
+
+			w := &Writer{Conflict: ConflictAsk, Interactive: true, In: strings.NewReader(tt.input), Out: io.Discard}
+			if err := w.Write(set); err != nil {
+				t.Fatal(err)
+			}
+			_, err := os.Stat(snapshot)
+			if written := err == nil; written != (tt.want == actionCreate) {
+				t.Errorf("snapshot ditulis = %v, want %v", written, tt.want == actionCreate)
+			}
+			if tt.input == "n\n" {
+				if got := readFile(t, entity+".new"); got != "baru\n" {
+					t.Errorf("product.go.new = %q, want isi baru", got)
+				}
+			}
+		})
+	}
+}
+
+// Stdin yang diarahkan dari /dev/null bukan terminal walaupun berupa
+// character device
+func TestIsTerminalDevNull(t *testing.T) {
+	f, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if isTerminal(f) {
+		t.Errorf("isTerminal(%s) = true, want false", os.DevNull)
+	}
+}